@@ -26,6 +26,7 @@ const (
 	nitricServicePortDefault        = "50051"
 	nitricServiceDialTimeoutDefault = "5000"
 	nitricServiceAddress            = "SERVICE_ADDRESS"
+	nitricServiceDialTimeout        = "SERVICE_DIAL_TIMEOUT"
 )
 
 // getEnvWithFallback - Returns an envirable variable's value from its name or a default value if the variable isn't set
@@ -37,9 +38,12 @@ func GetEnvWithFallback(varName string, fallback string) string {
 	}
 }
 
-// NitricDialTimeout - Retrieves default service dial timeout in milliseconds
+// NitricDialTimeout - Retrieves how long to wait for the Nitric server to become reachable, set in milliseconds by SERVICE_DIAL_TIMEOUT
 func NitricDialTimeout() time.Duration {
-	tInt, _ := strconv.ParseInt(nitricServiceDialTimeoutDefault, 10, 64)
+	tInt, err := strconv.ParseInt(GetEnvWithFallback(nitricServiceDialTimeout, nitricServiceDialTimeoutDefault), 10, 64)
+	if err != nil || tInt <= 0 {
+		tInt, _ = strconv.ParseInt(nitricServiceDialTimeoutDefault, 10, 64)
+	}
 
 	return time.Duration(tInt) * time.Millisecond
 }
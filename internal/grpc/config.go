@@ -0,0 +1,236 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcx
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+
+	"github.com/nitrictech/go-sdk/constants"
+)
+
+// Config configures the connection to the Nitric server.
+type Config struct {
+	// Address of the Nitric server, either host:port or a unix socket, e.g. unix:///var/run/nitric.sock.
+	Address string
+
+	// TLS enables TLS, verifying the server with the system's root CAs unless TLSCAFile or TLSConfig are set.
+	TLS bool
+	// TLSConfig is the TLS config used to connect, enabling TLS. The TLS file settings are applied on top of it.
+	TLSConfig *tls.Config
+	// TLSCAFile is a PEM file of CA certificates used to verify the server, enabling TLS.
+	TLSCAFile string
+	// TLSCertFile and TLSKeyFile are the PEM client certificate and key presented to the server for mutual TLS, enabling TLS.
+	TLSCertFile string
+	TLSKeyFile  string
+	// TLSServerName overrides the server name used to verify the server's certificate.
+	TLSServerName string
+
+	// Keepalive sets the keepalive parameters of the connection, if any.
+	Keepalive *keepalive.ClientParameters
+
+	// MaxRecvMsgSize and MaxSendMsgSize set the maximum message sizes in bytes, if greater than zero.
+	MaxRecvMsgSize int
+	MaxSendMsgSize int
+
+	// ConnectParams sets the backoff used to retry connecting to the Nitric server, and the minimum time to wait for each attempt.
+	ConnectParams *grpc.ConnectParams
+
+	// DialOptions are added after the options from the rest of the config.
+	DialOptions []grpc.DialOption
+}
+
+const (
+	envTLS                   = "NITRIC_TLS"
+	envTLSCAFile             = "NITRIC_TLS_CA_FILE"
+	envTLSCertFile           = "NITRIC_TLS_CERT_FILE"
+	envTLSKeyFile            = "NITRIC_TLS_KEY_FILE"
+	envTLSServerName         = "NITRIC_TLS_SERVER_NAME"
+	envKeepaliveTime         = "NITRIC_KEEPALIVE_TIME"
+	envKeepaliveTimeout      = "NITRIC_KEEPALIVE_TIMEOUT"
+	envKeepalivePermitStream = "NITRIC_KEEPALIVE_PERMIT_WITHOUT_STREAM"
+	envMaxRecvMsgSize        = "NITRIC_MAX_RECV_MSG_SIZE"
+	envMaxSendMsgSize        = "NITRIC_MAX_SEND_MSG_SIZE"
+)
+
+// ConfigFromEnv returns the config set by the environment.
+//
+// The address is read from SERVICE_ADDRESS and the rest of the config from NITRIC_ prefixed variables,
+// durations use time.ParseDuration's format, e.g. "30s".
+func ConfigFromEnv() (Config, error) {
+	cfg := Config{
+		Address:       constants.NitricAddress(),
+		TLSCAFile:     os.Getenv(envTLSCAFile),
+		TLSCertFile:   os.Getenv(envTLSCertFile),
+		TLSKeyFile:    os.Getenv(envTLSKeyFile),
+		TLSServerName: os.Getenv(envTLSServerName),
+	}
+
+	var err error
+
+	if cfg.TLS, err = parseEnv(envTLS, strconv.ParseBool); err != nil {
+		return Config{}, err
+	}
+
+	keepaliveTime, err := parseEnv(envKeepaliveTime, time.ParseDuration)
+	if err != nil {
+		return Config{}, err
+	}
+
+	keepaliveTimeout, err := parseEnv(envKeepaliveTimeout, time.ParseDuration)
+	if err != nil {
+		return Config{}, err
+	}
+
+	permitWithoutStream, err := parseEnv(envKeepalivePermitStream, strconv.ParseBool)
+	if err != nil {
+		return Config{}, err
+	}
+
+	if keepaliveTime > 0 || keepaliveTimeout > 0 || permitWithoutStream {
+		cfg.Keepalive = &keepalive.ClientParameters{
+			Time:                keepaliveTime,
+			Timeout:             keepaliveTimeout,
+			PermitWithoutStream: permitWithoutStream,
+		}
+	}
+
+	if cfg.MaxRecvMsgSize, err = parseEnv(envMaxRecvMsgSize, strconv.Atoi); err != nil {
+		return Config{}, err
+	}
+
+	if cfg.MaxSendMsgSize, err = parseEnv(envMaxSendMsgSize, strconv.Atoi); err != nil {
+		return Config{}, err
+	}
+
+	return cfg, nil
+}
+
+func parseEnv[T any](name string, parse func(string) (T, error)) (T, error) {
+	var zero T
+
+	value := os.Getenv(name)
+	if value == "" {
+		return zero, nil
+	}
+
+	parsed, err := parse(value)
+	if err != nil {
+		return zero, fmt.Errorf("invalid %s: %w", name, err)
+	}
+
+	return parsed, nil
+}
+
+func (c Config) tlsEnabled() bool {
+	return c.TLS || c.TLSConfig != nil || c.TLSCAFile != "" || c.TLSCertFile != "" || c.TLSKeyFile != ""
+}
+
+func (c Config) tlsConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if c.TLSConfig != nil {
+		tlsConfig = c.TLSConfig.Clone()
+	}
+
+	if c.TLSServerName != "" {
+		tlsConfig.ServerName = c.TLSServerName
+	}
+
+	if c.TLSCAFile != "" {
+		pem, err := os.ReadFile(c.TLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read TLS CA file: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in TLS CA file %s", c.TLSCAFile)
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if c.TLSCertFile != "" || c.TLSKeyFile != "" {
+		if c.TLSCertFile == "" || c.TLSKeyFile == "" {
+			return nil, fmt.Errorf("both a TLS certificate and key file are required for mutual TLS")
+		}
+
+		cert, err := tls.LoadX509KeyPair(c.TLSCertFile, c.TLSKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load TLS client certificate: %w", err)
+		}
+
+		tlsConfig.Certificates = append(tlsConfig.Certificates, cert)
+	}
+
+	return tlsConfig, nil
+}
+
+func (c Config) dialOptions() ([]grpc.DialOption, error) {
+	opts := constants.DefaultOptions()
+
+	if c.tlsEnabled() {
+		tlsConfig, err := c.tlsConfig()
+		if err != nil {
+			return nil, err
+		}
+
+		opts = []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))}
+	}
+
+	if c.Keepalive != nil {
+		opts = append(opts, grpc.WithKeepaliveParams(*c.Keepalive))
+	}
+
+	if c.ConnectParams != nil {
+		opts = append(opts, grpc.WithConnectParams(*c.ConnectParams))
+	}
+
+	callOpts := []grpc.CallOption{}
+	if c.MaxRecvMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallRecvMsgSize(c.MaxRecvMsgSize))
+	}
+	if c.MaxSendMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallSendMsgSize(c.MaxSendMsgSize))
+	}
+	if len(callOpts) > 0 {
+		opts = append(opts, grpc.WithDefaultCallOptions(callOpts...))
+	}
+
+	return append(opts, c.DialOptions...), nil
+}
+
+// Dial creates a connection to the Nitric server with the config.
+func (c Config) Dial() (*grpc.ClientConn, error) {
+	opts, err := c.dialOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	address := c.Address
+	if address == "" {
+		address = constants.NitricAddress()
+	}
+
+	return grpc.NewClient(address, opts...)
+}
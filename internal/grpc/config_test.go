@@ -0,0 +1,246 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcx
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
+)
+
+// serve starts a gRPC server with a health service on the listener.
+func serve(lis net.Listener, opts ...grpc.ServerOption) *grpc.Server {
+	srv := grpc.NewServer(opts...)
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+
+	return srv
+}
+
+// writeCert writes a self-signed certificate and key for localhost to the dir, returning their paths.
+func writeCert(dir string) (certFile string, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).ToNot(HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		DNSNames:              []string{"localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).ToNot(HaveOccurred())
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	Expect(err).ToNot(HaveOccurred())
+
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+
+	Expect(os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600)).To(Succeed())
+	Expect(os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600)).To(Succeed())
+
+	return certFile, keyFile
+}
+
+func checkHealth(conn grpc.ClientConnInterface, service string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+	return err
+}
+
+var _ = Describe("Config", func() {
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "grpcx")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	Describe("ConfigFromEnv", func() {
+		var set []string
+
+		setEnv := func(env map[string]string) {
+			for k, v := range env {
+				os.Setenv(k, v)
+				set = append(set, k)
+			}
+		}
+
+		AfterEach(func() {
+			for _, k := range set {
+				os.Unsetenv(k)
+			}
+			set = nil
+		})
+
+		It("should read the config from the environment", func() {
+			setEnv(map[string]string{
+				"SERVICE_ADDRESS":                        "unix:///tmp/nitric.sock",
+				"NITRIC_TLS":                             "true",
+				"NITRIC_TLS_CA_FILE":                     "ca.pem",
+				"NITRIC_TLS_CERT_FILE":                   "cert.pem",
+				"NITRIC_TLS_KEY_FILE":                    "key.pem",
+				"NITRIC_TLS_SERVER_NAME":                 "nitric",
+				"NITRIC_KEEPALIVE_TIME":                  "30s",
+				"NITRIC_KEEPALIVE_TIMEOUT":               "5s",
+				"NITRIC_MAX_RECV_MSG_SIZE":               "1024",
+				"NITRIC_MAX_SEND_MSG_SIZE":               "2048",
+				"NITRIC_KEEPALIVE_PERMIT_WITHOUT_STREAM": "true",
+			})
+
+			config, err := ConfigFromEnv()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config).To(Equal(Config{
+				Address:       "unix:///tmp/nitric.sock",
+				TLS:           true,
+				TLSCAFile:     "ca.pem",
+				TLSCertFile:   "cert.pem",
+				TLSKeyFile:    "key.pem",
+				TLSServerName: "nitric",
+				Keepalive: &keepalive.ClientParameters{
+					Time:                30 * time.Second,
+					Timeout:             5 * time.Second,
+					PermitWithoutStream: true,
+				},
+				MaxRecvMsgSize: 1024,
+				MaxSendMsgSize: 2048,
+			}))
+		})
+
+		It("should return an error for invalid values", func() {
+			setEnv(map[string]string{"NITRIC_KEEPALIVE_TIME": "soon"})
+
+			_, err := ConfigFromEnv()
+			Expect(err).To(MatchError(ContainSubstring("NITRIC_KEEPALIVE_TIME")))
+		})
+	})
+
+	Describe("Dial", func() {
+		It("should connect to a unix socket", func() {
+			socket := filepath.Join(dir, "nitric.sock")
+			lis, err := net.Listen("unix", socket)
+			Expect(err).ToNot(HaveOccurred())
+			defer serve(lis).Stop()
+
+			conn, err := Config{
+				Address:   "unix://" + socket,
+				Keepalive: &keepalive.ClientParameters{Time: time.Minute},
+			}.Dial()
+			Expect(err).ToNot(HaveOccurred())
+			defer conn.Close()
+
+			Expect(checkHealth(conn, "")).To(Succeed())
+		})
+
+		It("should limit the size of sent messages", func() {
+			lis, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).ToNot(HaveOccurred())
+			defer serve(lis).Stop()
+
+			conn, err := Config{Address: lis.Addr().String(), MaxSendMsgSize: 16}.Dial()
+			Expect(err).ToNot(HaveOccurred())
+			defer conn.Close()
+
+			err = checkHealth(conn, strings.Repeat("a", 32))
+			Expect(status.Code(err)).To(Equal(codes.ResourceExhausted))
+		})
+
+		It("should connect with mutual TLS", func() {
+			certFile, keyFile := writeCert(dir)
+
+			cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+			Expect(err).ToNot(HaveOccurred())
+
+			pool := x509.NewCertPool()
+			pemBytes, err := os.ReadFile(certFile)
+			Expect(err).ToNot(HaveOccurred())
+			pool.AppendCertsFromPEM(pemBytes)
+
+			lis, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).ToNot(HaveOccurred())
+			srv := serve(lis, grpc.Creds(credentials.NewTLS(&tls.Config{
+				Certificates: []tls.Certificate{cert},
+				ClientCAs:    pool,
+				ClientAuth:   tls.RequireAndVerifyClientCert,
+			})))
+			defer srv.Stop()
+
+			By("failing without a client certificate")
+			conn, err := Config{Address: lis.Addr().String(), TLSCAFile: certFile, TLSServerName: "localhost"}.Dial()
+			Expect(err).ToNot(HaveOccurred())
+			defer conn.Close()
+
+			Expect(checkHealth(conn, "")).ToNot(Succeed())
+
+			By("succeeding with a client certificate")
+			mtlsConn, err := Config{
+				Address:       lis.Addr().String(),
+				TLSCAFile:     certFile,
+				TLSCertFile:   certFile,
+				TLSKeyFile:    keyFile,
+				TLSServerName: "localhost",
+			}.Dial()
+			Expect(err).ToNot(HaveOccurred())
+			defer mtlsConn.Close()
+
+			Expect(checkHealth(mtlsConn, "")).To(Succeed())
+		})
+
+		It("should require both a certificate and key for mutual TLS", func() {
+			certFile, _ := writeCert(dir)
+
+			_, err := Config{TLSCertFile: certFile}.Dial()
+			Expect(err).To(MatchError(ContainSubstring("both a TLS certificate and key file are required")))
+		})
+	})
+})
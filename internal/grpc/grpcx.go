@@ -15,15 +15,17 @@
 package grpcx
 
 import (
+	"errors"
 	"sync"
 
 	"google.golang.org/grpc"
 
-	"github.com/nitrictech/go-sdk/constants"
+	"github.com/nitrictech/go-sdk/nitric/retry"
 )
 
 type grpcManager struct {
 	conn      grpc.ClientConnInterface
+	config    *Config
 	connMutex sync.Mutex
 }
 
@@ -32,12 +34,39 @@ var m = grpcManager{
 	connMutex: sync.Mutex{},
 }
 
+// ErrConnected is returned when configuring the connection after it has been created.
+var ErrConnected = errors.New("the connection to the nitric server has already been created")
+
+// SetConfig sets the config of the shared connection, replacing the config from the environment.
+// It must be called before the connection is created.
+func SetConfig(config Config) error {
+	m.connMutex.Lock()
+	defer m.connMutex.Unlock()
+
+	if m.conn != nil {
+		return ErrConnected
+	}
+
+	m.config = &config
+
+	return nil
+}
+
 func GetConnection() (grpc.ClientConnInterface, error) {
 	m.connMutex.Lock()
 	defer m.connMutex.Unlock()
 
 	if m.conn == nil {
-		conn, err := grpc.NewClient(constants.NitricAddress(), constants.DefaultOptions()...)
+		config := m.config
+		if config == nil {
+			envConfig, err := ConfigFromEnv()
+			if err != nil {
+				return nil, err
+			}
+			config = &envConfig
+		}
+
+		conn, err := config.Dial()
 		if err != nil {
 			return nil, err
 		}
@@ -46,3 +75,65 @@ func GetConnection() (grpc.ClientConnInterface, error) {
 
 	return m.conn, nil
 }
+
+// SetConnection replaces the shared connection used by resources, clients and workers.
+// Resources declared and clients created before the connection is replaced keep using the previous connection.
+func SetConnection(conn grpc.ClientConnInterface) {
+	m.connMutex.Lock()
+	defer m.connMutex.Unlock()
+
+	m.conn = conn
+}
+
+// ClientOptions are the options of the SDK's clients.
+type ClientOptions struct {
+	Connection  grpc.ClientConnInterface
+	RetryPolicy *retry.Policy
+}
+
+// ClientOption configures a client.
+type ClientOption func(opts *ClientOptions)
+
+// WithConnection - Use the connection for the client instead of the shared connection
+func WithConnection(conn grpc.ClientConnInterface) ClientOption {
+	return func(opts *ClientOptions) {
+		opts.Connection = conn
+	}
+}
+
+// WithRetryPolicy - Retry the client's calls with the policy instead of the default policy
+func WithRetryPolicy(policy retry.Policy) ClientOption {
+	return func(opts *ClientOptions) {
+		opts.RetryPolicy = &policy
+	}
+}
+
+// ClientConnection returns the connection set by the options, or the shared connection if none is set.
+// Calls made on the connection run through the global interceptors, and are retried with the retry policy from the options.
+func ClientConnection(opts ...ClientOption) (grpc.ClientConnInterface, error) {
+	options := &ClientOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	conn := options.Connection
+	if conn == nil {
+		var err error
+
+		conn, err = GetConnection()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	intercepted := Intercept(conn, nil).(*interceptedConn)
+	if options.RetryPolicy != nil {
+		intercepted = &interceptedConn{
+			conn:        intercepted.conn,
+			layers:      intercepted.layers,
+			retryPolicy: options.RetryPolicy,
+		}
+	}
+
+	return intercepted, nil
+}
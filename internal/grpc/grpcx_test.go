@@ -0,0 +1,70 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcx
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+)
+
+type testConn struct {
+	grpc.ClientConnInterface
+	name string
+}
+
+var _ = Describe("Connection", func() {
+	var previous grpc.ClientConnInterface
+
+	BeforeEach(func() {
+		previous = m.conn
+	})
+
+	AfterEach(func() {
+		SetConnection(previous)
+	})
+
+	It("should use the connection set with SetConnection", func() {
+		shared := &testConn{name: "shared"}
+		SetConnection(shared)
+
+		conn, err := GetConnection()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(conn).To(BeIdenticalTo(shared))
+
+		By("not allowing the config to be changed")
+		Expect(SetConfig(Config{})).To(MatchError(ErrConnected))
+	})
+
+	Describe("ClientConnection", func() {
+		It("should use the shared connection by default", func() {
+			shared := &testConn{name: "shared"}
+			SetConnection(shared)
+
+			conn, err := ClientConnection()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(conn.(*interceptedConn).conn).To(BeIdenticalTo(shared))
+		})
+
+		It("should use the connection from the options", func() {
+			SetConnection(&testConn{name: "shared"})
+			client := &testConn{name: "client"}
+
+			conn, err := ClientConnection(WithConnection(client))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(conn.(*interceptedConn).conn).To(BeIdenticalTo(client))
+		})
+	})
+})
@@ -0,0 +1,162 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcx
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"google.golang.org/grpc"
+
+	"github.com/nitrictech/go-sdk/nitric/retry"
+)
+
+// Interceptors are client interceptors applied to calls made on connections wrapped with Intercept.
+//
+// Interceptors are read as each call is made, so they also apply to clients created before they were added.
+type Interceptors struct {
+	mutex  sync.RWMutex
+	unary  []grpc.UnaryClientInterceptor
+	stream []grpc.StreamClientInterceptor
+}
+
+// AddUnary adds interceptors that run, in the order they are added, around unary calls.
+func (i *Interceptors) AddUnary(interceptors ...grpc.UnaryClientInterceptor) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	i.unary = append(i.unary, interceptors...)
+}
+
+// AddStream adds interceptors that run, in the order they are added, around streams.
+func (i *Interceptors) AddStream(interceptors ...grpc.StreamClientInterceptor) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	i.stream = append(i.stream, interceptors...)
+}
+
+func (i *Interceptors) getUnary() []grpc.UnaryClientInterceptor {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+
+	return i.unary
+}
+
+func (i *Interceptors) getStream() []grpc.StreamClientInterceptor {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+
+	return i.stream
+}
+
+var globalInterceptors = &Interceptors{}
+
+// AddUnaryInterceptors adds unary interceptors applied to calls on every connection.
+func AddUnaryInterceptors(interceptors ...grpc.UnaryClientInterceptor) {
+	globalInterceptors.AddUnary(interceptors...)
+}
+
+// AddStreamInterceptors adds stream interceptors applied to streams on every connection.
+func AddStreamInterceptors(interceptors ...grpc.StreamClientInterceptor) {
+	globalInterceptors.AddStream(interceptors...)
+}
+
+// interceptedConn runs calls through the global interceptors, followed by each of its layers of interceptors.
+// Unary calls are retried around the interceptors, with the client's retry policy if it has one.
+type interceptedConn struct {
+	conn        grpc.ClientConnInterface
+	layers      []*Interceptors
+	retryPolicy *retry.Policy
+}
+
+var _ grpc.ClientConnInterface = &interceptedConn{}
+
+// Intercept wraps the connection so calls made on it run through the global interceptors, followed by the given interceptors.
+// The interceptors may be nil to only apply the global interceptors.
+//
+// Wrapping an intercepted connection adds the interceptors as another layer, the global interceptors are only applied once.
+func Intercept(conn grpc.ClientConnInterface, interceptors *Interceptors) grpc.ClientConnInterface {
+	intercepted := &interceptedConn{conn: conn}
+
+	if existing, ok := conn.(*interceptedConn); ok {
+		if interceptors == nil || slices.Contains(existing.layers, interceptors) {
+			return existing
+		}
+
+		intercepted.conn = existing.conn
+		intercepted.layers = slices.Clone(existing.layers)
+		intercepted.retryPolicy = existing.retryPolicy
+	}
+
+	if interceptors != nil {
+		intercepted.layers = append(intercepted.layers, interceptors)
+	}
+
+	return intercepted
+}
+
+// clientConn returns the wrapped connection to pass to interceptors, it is nil if the connection isn't a *grpc.ClientConn.
+func (c *interceptedConn) clientConn() *grpc.ClientConn {
+	cc, _ := c.conn.(*grpc.ClientConn)
+	return cc
+}
+
+func (c *interceptedConn) Invoke(ctx context.Context, method string, args any, reply any, opts ...grpc.CallOption) error {
+	interceptors := globalInterceptors.getUnary()
+	for _, layer := range c.layers {
+		interceptors = append(slices.Clip(interceptors), layer.getUnary()...)
+	}
+
+	var invoker func(i int) grpc.UnaryInvoker
+	invoker = func(i int) grpc.UnaryInvoker {
+		if i == len(interceptors) {
+			return func(ctx context.Context, method string, req, reply any, _ *grpc.ClientConn, opts ...grpc.CallOption) error {
+				return c.conn.Invoke(ctx, method, req, reply, opts...)
+			}
+		}
+
+		return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			return interceptors[i](ctx, method, req, reply, cc, invoker(i+1), opts...)
+		}
+	}
+
+	return invokeWithRetry(ctx, method, c.retryPolicy, func() error {
+		return invoker(0)(ctx, method, args, reply, c.clientConn(), opts...)
+	})
+}
+
+func (c *interceptedConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	interceptors := globalInterceptors.getStream()
+	for _, layer := range c.layers {
+		interceptors = append(slices.Clip(interceptors), layer.getStream()...)
+	}
+
+	var streamer func(i int) grpc.Streamer
+	streamer = func(i int) grpc.Streamer {
+		if i == len(interceptors) {
+			return func(ctx context.Context, desc *grpc.StreamDesc, _ *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+				return c.conn.NewStream(ctx, desc, method, opts...)
+			}
+		}
+
+		return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			return interceptors[i](ctx, desc, cc, method, streamer(i+1), opts...)
+		}
+	}
+
+	return streamer(0)(ctx, desc, c.clientConn(), method, opts...)
+}
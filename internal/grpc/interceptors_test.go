@@ -0,0 +1,104 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcx
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+)
+
+// recordingConn records the calls that reach it.
+type recordingConn struct {
+	calls *[]string
+}
+
+func (r recordingConn) Invoke(ctx context.Context, method string, args any, reply any, opts ...grpc.CallOption) error {
+	*r.calls = append(*r.calls, "invoke "+method)
+	return nil
+}
+
+func (r recordingConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	*r.calls = append(*r.calls, "stream "+method)
+	return nil, nil
+}
+
+func recordUnary(calls *[]string, name string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		*calls = append(*calls, name)
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func recordStream(calls *[]string, name string) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		*calls = append(*calls, name)
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
+
+var _ = Describe("Intercept", func() {
+	var (
+		previous *Interceptors
+		calls    []string
+		conn     recordingConn
+	)
+
+	BeforeEach(func() {
+		previous = globalInterceptors
+		globalInterceptors = &Interceptors{}
+		calls = []string{}
+		conn = recordingConn{calls: &calls}
+	})
+
+	AfterEach(func() {
+		globalInterceptors = previous
+	})
+
+	It("should run the global interceptors before the connection's interceptors", func() {
+		local := &Interceptors{}
+		local.AddUnary(recordUnary(&calls, "local"))
+		local.AddStream(recordStream(&calls, "local stream"))
+
+		intercepted := Intercept(conn, local)
+
+		By("applying interceptors added after the connection was wrapped")
+		AddUnaryInterceptors(recordUnary(&calls, "global 1"), recordUnary(&calls, "global 2"))
+		AddStreamInterceptors(recordStream(&calls, "global stream"))
+
+		Expect(intercepted.Invoke(context.TODO(), "/test/Unary", nil, nil)).To(Succeed())
+		_, err := intercepted.NewStream(context.TODO(), &grpc.StreamDesc{}, "/test/Stream")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(calls).To(Equal([]string{
+			"global 1", "global 2", "local", "invoke /test/Unary",
+			"global stream", "local stream", "stream /test/Stream",
+		}))
+	})
+
+	It("should only apply the global interceptors once when wrapped again", func() {
+		AddUnaryInterceptors(recordUnary(&calls, "global"))
+
+		local := &Interceptors{}
+		local.AddUnary(recordUnary(&calls, "local"))
+
+		intercepted := Intercept(Intercept(Intercept(conn, nil), local), local)
+
+		Expect(intercepted.Invoke(context.TODO(), "/test/Unary", nil, nil)).To(Succeed())
+		Expect(calls).To(Equal([]string{"global", "local", "invoke /test/Unary"}))
+	})
+})
@@ -0,0 +1,100 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcx
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/status"
+
+	apierrors "github.com/nitrictech/go-sdk/nitric/errors"
+	"github.com/nitrictech/go-sdk/nitric/errors/codes"
+	"github.com/nitrictech/go-sdk/nitric/retry"
+)
+
+// idempotentMethods have the same effect when they're repeated, so they are retried by default.
+var idempotentMethods = map[string]bool{
+	"/nitric.proto.kvstore.v1.KvStore/GetValue":             true,
+	"/nitric.proto.kvstore.v1.KvStore/SetValue":             true,
+	"/nitric.proto.kvstore.v1.KvStore/DeleteKey":            true,
+	"/nitric.proto.storage.v1.Storage/Read":                 true,
+	"/nitric.proto.storage.v1.Storage/Write":                true,
+	"/nitric.proto.storage.v1.Storage/Delete":               true,
+	"/nitric.proto.storage.v1.Storage/Exists":               true,
+	"/nitric.proto.storage.v1.Storage/ListBlobs":            true,
+	"/nitric.proto.storage.v1.Storage/PreSignUrl":           true,
+	"/nitric.proto.secrets.v1.SecretManager/Access":         true,
+	"/nitric.proto.sql.v1.Sql/ConnectionString":             true,
+	"/nitric.proto.websockets.v1.Websocket/SocketDetails":   true,
+	"/nitric.proto.websockets.v1.Websocket/CloseConnection": true,
+}
+
+// nonIdempotentMethods may be applied more than once when they're retried, so they are only retried when the policy allows it.
+var nonIdempotentMethods = map[string]bool{
+	"/nitric.proto.topics.v1.Topics/Publish":            true,
+	"/nitric.proto.queues.v1.Queues/Enqueue":            true,
+	"/nitric.proto.queues.v1.Queues/Dequeue":            true,
+	"/nitric.proto.batch.v1.Batch/SubmitJob":            true,
+	"/nitric.proto.secrets.v1.SecretManager/Put":        true,
+	"/nitric.proto.websockets.v1.Websocket/SendMessage": true,
+}
+
+// retryPolicy returns the policy for the call, from its context, then the client, then the default policy.
+func retryPolicy(ctx context.Context, clientPolicy *retry.Policy) retry.Policy {
+	if policy, ok := retry.FromContext(ctx); ok {
+		return policy
+	}
+
+	if clientPolicy != nil {
+		return *clientPolicy
+	}
+
+	return retry.Default()
+}
+
+func errorCode(err error) codes.Code {
+	if s, ok := status.FromError(err); ok {
+		return codes.Code(s.Code())
+	}
+
+	return apierrors.Code(err)
+}
+
+// invokeWithRetry makes the call, retrying it with backoff while it fails with a retryable code.
+// Methods that aren't known client operations, such as resource declarations, are never retried.
+func invokeWithRetry(ctx context.Context, method string, clientPolicy *retry.Policy, call func() error) error {
+	policy := retryPolicy(ctx, clientPolicy)
+
+	retryable := idempotentMethods[method] || (policy.NonIdempotent && nonIdempotentMethods[method])
+	if !retryable || policy.MaxAttempts < 2 {
+		return call()
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := call()
+		if err == nil || attempt >= policy.MaxAttempts || !policy.Retryable(errorCode(err)) || ctx.Err() != nil {
+			return err
+		}
+
+		timer := time.NewTimer(policy.Backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcx
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/nitrictech/go-sdk/nitric/errors/codes"
+	"github.com/nitrictech/go-sdk/nitric/retry"
+)
+
+// flakyConn fails calls with the given codes, in order, then succeeds.
+type flakyConn struct {
+	grpc.ClientConnInterface
+	failures []grpccodes.Code
+	calls    int
+}
+
+func (f *flakyConn) Invoke(ctx context.Context, method string, args any, reply any, opts ...grpc.CallOption) error {
+	f.calls++
+	if f.calls <= len(f.failures) {
+		return status.Error(f.failures[f.calls-1], "flaky")
+	}
+
+	return nil
+}
+
+var _ = Describe("Retries", func() {
+	var (
+		previous retry.Policy
+		conn     *flakyConn
+	)
+
+	BeforeEach(func() {
+		previous = retry.Default()
+
+		policy := retry.DefaultPolicy()
+		policy.InitialBackoff = time.Millisecond
+		retry.SetDefault(policy)
+
+		conn = &flakyConn{failures: []grpccodes.Code{grpccodes.Unavailable, grpccodes.DeadlineExceeded}}
+	})
+
+	AfterEach(func() {
+		retry.SetDefault(previous)
+	})
+
+	It("should retry idempotent calls with the default policy", func() {
+		Expect(Intercept(conn, nil).Invoke(context.TODO(), "/nitric.proto.kvstore.v1.KvStore/GetValue", nil, nil)).To(Succeed())
+		Expect(conn.calls).To(Equal(3))
+	})
+
+	It("should stop retrying after the max attempts", func() {
+		conn.failures = append(conn.failures, grpccodes.Unavailable)
+
+		err := Intercept(conn, nil).Invoke(context.TODO(), "/nitric.proto.storage.v1.Storage/Read", nil, nil)
+		Expect(status.Code(err)).To(Equal(grpccodes.Unavailable))
+		Expect(conn.calls).To(Equal(3))
+	})
+
+	It("should not retry codes that aren't retryable", func() {
+		conn.failures = []grpccodes.Code{grpccodes.NotFound}
+
+		err := Intercept(conn, nil).Invoke(context.TODO(), "/nitric.proto.secrets.v1.SecretManager/Access", nil, nil)
+		Expect(status.Code(err)).To(Equal(grpccodes.NotFound))
+		Expect(conn.calls).To(Equal(1))
+	})
+
+	It("should only retry non-idempotent calls when the policy allows it", func() {
+		err := Intercept(conn, nil).Invoke(context.TODO(), "/nitric.proto.topics.v1.Topics/Publish", nil, nil)
+		Expect(status.Code(err)).To(Equal(grpccodes.Unavailable))
+		Expect(conn.calls).To(Equal(1))
+
+		By("overriding the policy for the call")
+		conn.calls = 0
+		policy := retry.Default()
+		policy.NonIdempotent = true
+
+		ctx := retry.WithPolicy(context.TODO(), policy)
+		Expect(Intercept(conn, nil).Invoke(ctx, "/nitric.proto.topics.v1.Topics/Publish", nil, nil)).To(Succeed())
+		Expect(conn.calls).To(Equal(3))
+	})
+
+	It("should never retry calls that aren't client operations", func() {
+		policy := retry.Default()
+		policy.NonIdempotent = true
+
+		ctx := retry.WithPolicy(context.TODO(), policy)
+		Expect(Intercept(conn, nil).Invoke(ctx, "/nitric.proto.resources.v1.Resources/Declare", nil, nil)).ToNot(Succeed())
+		Expect(conn.calls).To(Equal(1))
+	})
+
+	It("should use the policy from the client options", func() {
+		client, err := ClientConnection(WithConnection(conn), WithRetryPolicy(retry.Disabled()))
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(client.Invoke(context.TODO(), "/nitric.proto.kvstore.v1.KvStore/SetValue", nil, nil)).ToNot(Succeed())
+		Expect(conn.calls).To(Equal(1))
+	})
+
+	It("should stop retrying when the context is done", func() {
+		conn.failures = []grpccodes.Code{grpccodes.Unavailable, grpccodes.Unavailable}
+
+		policy := retry.Default()
+		policy.InitialBackoff = time.Minute
+
+		ctx, cancel := context.WithTimeout(retry.WithPolicy(context.TODO(), policy), 10*time.Millisecond)
+		defer cancel()
+
+		Expect(Intercept(conn, nil).Invoke(ctx, "/nitric.proto.kvstore.v1.KvStore/DeleteKey", nil, nil)).ToNot(Succeed())
+		Expect(conn.calls).To(Equal(1))
+	})
+
+	It("should back off exponentially up to the max backoff", func() {
+		policy := retry.Policy{
+			InitialBackoff:    100 * time.Millisecond,
+			MaxBackoff:        time.Second,
+			BackoffMultiplier: 4,
+			RetryableCodes:    []codes.Code{codes.Unavailable},
+		}
+
+		Expect(policy.Backoff(1)).To(Equal(100 * time.Millisecond))
+		Expect(policy.Backoff(2)).To(Equal(400 * time.Millisecond))
+		Expect(policy.Backoff(3)).To(Equal(time.Second))
+		Expect(policy.Retryable(codes.Unavailable)).To(BeTrue())
+		Expect(policy.Retryable(codes.NotFound)).To(BeFalse())
+	})
+})
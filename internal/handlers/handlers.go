@@ -56,3 +56,22 @@ func HandlerFromInterface[T any](handler interface{}) (Handler[T], error) {
 
 	return typedHandler, nil
 }
+
+// Chain wraps the handler in the given middleware.
+// The first middleware is the outermost, so it is called first and returns last.
+func Chain[T any](handler Handler[T], middlewares ...Middleware[T]) Handler[T] {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		if middlewares[i] != nil {
+			handler = middlewares[i](handler)
+		}
+	}
+
+	return handler
+}
+
+// Compose combines the given middleware into a single middleware, with the same ordering as Chain.
+func Compose[T any](middlewares ...Middleware[T]) Middleware[T] {
+	return func(next Handler[T]) Handler[T] {
+		return Chain(next, middlewares...)
+	}
+}
@@ -72,4 +72,53 @@ var _ = Describe("handler", func() {
 			})
 		})
 	})
+
+	Context("Chain", func() {
+		var calls []string
+
+		recordingMiddleware := func(name string) Middleware[string] {
+			return func(next Handler[string]) Handler[string] {
+				return func(ctx *string) error {
+					calls = append(calls, name+":before")
+					err := next(ctx)
+					calls = append(calls, name+":after")
+					return err
+				}
+			}
+		}
+
+		BeforeEach(func() {
+			calls = []string{}
+		})
+
+		handler := func(ctx *string) error {
+			calls = append(calls, "handler")
+			return nil
+		}
+
+		When("multiple middleware are provided", func() {
+			It("should call the first middleware first", func() {
+				chained := Chain(handler, recordingMiddleware("a"), nil, recordingMiddleware("b"))
+
+				Expect(chained(new(string))).To(Succeed())
+				Expect(calls).To(Equal([]string{"a:before", "b:before", "handler", "b:after", "a:after"}))
+			})
+		})
+
+		When("middleware are composed", func() {
+			It("should preserve the middleware order", func() {
+				composed := Compose(recordingMiddleware("a"), Compose(recordingMiddleware("b"), recordingMiddleware("c")))
+
+				Expect(composed(handler)(new(string))).To(Succeed())
+				Expect(calls).To(Equal([]string{"a:before", "b:before", "c:before", "handler", "c:after", "b:after", "a:after"}))
+			})
+		})
+
+		When("no middleware are provided", func() {
+			It("should return the handler", func() {
+				Expect(Chain(handler)(new(string))).To(Succeed())
+				Expect(calls).To(Equal([]string{"handler"}))
+			})
+		})
+	})
 })
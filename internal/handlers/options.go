@@ -0,0 +1,58 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handlers
+
+import "time"
+
+// Options are the options shared by the handlers of every trigger, such as topic subscribers and bucket listeners.
+type Options[T any] struct {
+	Middleware  []Middleware[T]
+	Timeout     time.Duration
+	MaxInFlight int
+}
+
+// Option configures the handler of a trigger.
+type Option[T any] func(opts *Options[T])
+
+// NewOptions returns the options with each option applied.
+func NewOptions[T any](opts ...Option[T]) *Options[T] {
+	options := &Options[T]{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return options
+}
+
+// WithMiddleware - Apply middleware functions to the handler, called in the order provided
+func WithMiddleware[T any](middleware ...Middleware[T]) Option[T] {
+	return func(opts *Options[T]) {
+		opts.Middleware = append(opts.Middleware, middleware...)
+	}
+}
+
+// WithTimeout - Set the maximum duration of each call to the handler, the Ctx's Context is cancelled once it elapses
+func WithTimeout[T any](timeout time.Duration) Option[T] {
+	return func(opts *Options[T]) {
+		opts.Timeout = timeout
+	}
+}
+
+// WithMaxInFlight - Set the maximum number of events handled concurrently, defaults to 1 which handles them in the order received
+func WithMaxInFlight[T any](maxInFlight int) Option[T] {
+	return func(opts *Options[T]) {
+		opts.MaxInFlight = maxInFlight
+	}
+}
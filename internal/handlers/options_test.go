@@ -0,0 +1,50 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handlers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Options", func() {
+	Context("NewOptions", func() {
+		It("should apply each option", func() {
+			first := func(next Handler[string]) Handler[string] { return next }
+			second := func(next Handler[string]) Handler[string] { return next }
+
+			options := NewOptions(
+				WithMiddleware(first),
+				WithMiddleware[string](second),
+				WithTimeout[string](time.Minute),
+				WithMaxInFlight[string](4),
+			)
+
+			Expect(options.Middleware).To(HaveLen(2))
+			Expect(options.Timeout).To(Equal(time.Minute))
+			Expect(options.MaxInFlight).To(Equal(4))
+		})
+
+		It("should leave the defaults unset without options", func() {
+			options := NewOptions[string]()
+
+			Expect(options.Middleware).To(BeEmpty())
+			Expect(options.Timeout).To(BeZero())
+			Expect(options.MaxInFlight).To(BeZero())
+		})
+	})
+})
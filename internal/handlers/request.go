@@ -0,0 +1,63 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handlers
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/nitrictech/go-sdk/nitric/workers"
+)
+
+// RequestContext is the context and logger of the request being handled, it is embedded in the Ctx of each trigger.
+type RequestContext struct {
+	ctx    context.Context
+	logger *slog.Logger
+}
+
+// Context returns the context of the request, it is cancelled when the worker stops or the handler's timeout elapses.
+func (c *RequestContext) Context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+
+	return c.ctx
+}
+
+// SetContext replaces the context of the request, e.g. to attach values to it in middleware.
+func (c *RequestContext) SetContext(ctx context.Context) {
+	c.ctx = ctx
+}
+
+// ShutdownSignal returns a channel that is closed when the application starts shutting down.
+// The context of the request stays usable until the shutdown timeout elapses, so handlers can use the signal to finish early.
+func (c *RequestContext) ShutdownSignal() <-chan struct{} {
+	return workers.ShutdownSignal(c.Context())
+}
+
+// Logger returns a logger with the attributes of the worker and message being handled.
+// If none is set it falls back to the logger of the context's worker, see workers.Logger.
+func (c *RequestContext) Logger() *slog.Logger {
+	if c.logger == nil {
+		return workers.Logger(c.Context())
+	}
+
+	return c.logger
+}
+
+// SetLogger replaces the logger of the request.
+func (c *RequestContext) SetLogger(logger *slog.Logger) {
+	c.logger = logger
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handlers
+
+import (
+	"context"
+	"log/slog"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/nitrictech/go-sdk/nitric/workers"
+)
+
+type contextKey struct{}
+
+var _ = Describe("RequestContext", func() {
+	It("should default to the background context and default logger", func() {
+		c := &RequestContext{}
+
+		Expect(c.Context()).To(Equal(context.Background()))
+		Expect(c.Logger()).To(Equal(slog.Default()))
+	})
+
+	It("should fall back to the default logger of the workers", func() {
+		logger := slog.Default().With("app", "test")
+		workers.SetDefaultLogger(logger)
+		defer workers.SetDefaultLogger(nil)
+
+		c := &RequestContext{}
+
+		Expect(c.Logger()).To(Equal(logger))
+	})
+
+	It("should return the context and logger that are set", func() {
+		c := &RequestContext{}
+		ctx := context.WithValue(context.Background(), contextKey{}, "value")
+		logger := slog.Default().With("worker", "test")
+
+		c.SetContext(ctx)
+		c.SetLogger(logger)
+
+		Expect(c.Context()).To(Equal(ctx))
+		Expect(c.Logger()).To(Equal(logger))
+	})
+
+	It("should not signal shutdown when the request isn't handled by a manager's worker", func() {
+		c := &RequestContext{}
+
+		Expect(c.ShutdownSignal()).To(BeNil())
+	})
+})
@@ -0,0 +1,142 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"net/textproto"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/metadata"
+)
+
+const instrumentationName = "github.com/nitrictech/go-sdk"
+
+// TraceContextKey is the reserved payload key of the trace context added to topic, queue and job messages,
+// when message propagation is enabled with SetMessagePropagation.
+const TraceContextKey = "_nitric_trace_context"
+
+// messagePropagation is true if the trace context is added to the payload of published messages.
+// The messages don't have metadata to carry it, so it's disabled by default to keep payloads unchanged for other consumers.
+var messagePropagation atomic.Bool
+
+// SetMessagePropagation sets whether the trace context is added to the payload of topic, queue and job messages under TraceContextKey.
+func SetMessagePropagation(enabled bool) {
+	messagePropagation.Store(enabled)
+}
+
+// propagator propagates W3C trace context and baggage, regardless of the global propagator.
+var propagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+// Tracer returns the SDK's tracer from the global tracer provider.
+func Tracer() trace.Tracer {
+	return otel.GetTracerProvider().Tracer(instrumentationName)
+}
+
+// Start starts a span of the given kind, for handling a trigger or making a call to the Nitric server.
+func Start(ctx context.Context, name string, kind trace.SpanKind, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithSpanKind(kind), trace.WithAttributes(attrs...))
+}
+
+// StartClient starts a client span for a call to the Nitric server, propagating the trace context in the gRPC metadata.
+// When tracing isn't configured the span isn't recorded and ctx is only changed to carry an existing trace context.
+func StartClient(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	spanCtx, span := Start(ctx, name, trace.SpanKindClient, attrs...)
+	if span.IsRecording() {
+		ctx = spanCtx
+	}
+
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+
+	for k, v := range carrier {
+		ctx = metadata.AppendToOutgoingContext(ctx, k, v)
+	}
+
+	return ctx, span
+}
+
+// End records the error, if any, on the span and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// ExtractHeaders returns a context with the trace context from the headers of an incoming request.
+func ExtractHeaders(ctx context.Context, headers map[string][]string) context.Context {
+	carrier := propagation.HeaderCarrier{}
+	for k, v := range headers {
+		carrier[textproto.CanonicalMIMEHeaderKey(k)] = v
+	}
+
+	return propagator.Extract(ctx, carrier)
+}
+
+// InjectMessage returns a copy of the message with the trace context of ctx added, for publishing to a topic, queue or job.
+// The message is returned unchanged if message propagation isn't enabled or ctx has no trace context.
+func InjectMessage(ctx context.Context, message map[string]interface{}) map[string]interface{} {
+	if !messagePropagation.Load() {
+		return message
+	}
+
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+
+	if len(carrier) == 0 {
+		return message
+	}
+
+	traceContext := make(map[string]interface{}, len(carrier))
+	for k, v := range carrier {
+		traceContext[k] = v
+	}
+
+	injected := make(map[string]interface{}, len(message)+1)
+	for k, v := range message {
+		injected[k] = v
+	}
+	injected[TraceContextKey] = traceContext
+
+	return injected
+}
+
+// ExtractMessage returns a context with the trace context from a received message payload.
+// The trace context is removed from the message, so it isn't seen by handlers.
+func ExtractMessage(ctx context.Context, message map[string]interface{}) context.Context {
+	traceContext, ok := message[TraceContextKey].(map[string]interface{})
+	if !ok {
+		return ctx
+	}
+
+	delete(message, TraceContextKey)
+
+	carrier := propagation.MapCarrier{}
+	for k, v := range traceContext {
+		if s, ok := v.(string); ok {
+			carrier[k] = s
+		}
+	}
+
+	return propagator.Extract(ctx, carrier)
+}
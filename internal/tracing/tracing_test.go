@@ -0,0 +1,134 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/nitrictech/go-sdk/internal/tracing"
+)
+
+var _ = Describe("Tracing", func() {
+	var (
+		recorder *tracetest.SpanRecorder
+		previous trace.TracerProvider
+	)
+
+	BeforeEach(func() {
+		previous = otel.GetTracerProvider()
+		recorder = tracetest.NewSpanRecorder()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	})
+
+	AfterEach(func() {
+		otel.SetTracerProvider(previous)
+	})
+
+	Describe("InjectMessage", func() {
+		When("the context has no trace", func() {
+			It("should return the message unchanged", func() {
+				message := map[string]interface{}{"test": "test"}
+
+				Expect(tracing.InjectMessage(context.Background(), message)).To(Equal(message))
+			})
+		})
+
+		When("message propagation isn't enabled", func() {
+			It("should return the message unchanged", func() {
+				ctx, span := tracing.Start(context.Background(), "publish", trace.SpanKindProducer)
+				defer span.End()
+
+				message := map[string]interface{}{"test": "test"}
+
+				Expect(tracing.InjectMessage(ctx, message)).To(Equal(message))
+			})
+		})
+
+		When("the context has a trace", func() {
+			BeforeEach(func() {
+				tracing.SetMessagePropagation(true)
+			})
+
+			AfterEach(func() {
+				tracing.SetMessagePropagation(false)
+			})
+
+			It("should round trip the trace context through ExtractMessage", func() {
+				ctx, span := tracing.Start(context.Background(), "publish", trace.SpanKindProducer)
+				defer span.End()
+
+				message := map[string]interface{}{"test": "test"}
+				injected := tracing.InjectMessage(ctx, message)
+
+				By("not modifying the original message")
+				Expect(message).ToNot(HaveKey(tracing.TraceContextKey))
+				Expect(injected).To(HaveKey(tracing.TraceContextKey))
+
+				By("restoring the trace and removing the trace context from the message")
+				extracted := trace.SpanContextFromContext(tracing.ExtractMessage(context.Background(), injected))
+				Expect(extracted.TraceID()).To(Equal(span.SpanContext().TraceID()))
+				Expect(extracted.SpanID()).To(Equal(span.SpanContext().SpanID()))
+				Expect(injected).To(Equal(message))
+			})
+		})
+	})
+
+	Describe("ExtractHeaders", func() {
+		It("should continue a trace from a traceparent header", func() {
+			ctx := tracing.ExtractHeaders(context.Background(), map[string][]string{
+				"traceparent": {"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+			})
+
+			_, span := tracing.Start(ctx, "GET /", trace.SpanKindServer)
+			span.End()
+
+			Expect(recorder.Ended()).To(HaveLen(1))
+			Expect(recorder.Ended()[0].Parent().TraceID().String()).To(Equal("4bf92f3577b34da6a3ce929d0e0e4736"))
+			Expect(recorder.Ended()[0].Parent().SpanID().String()).To(Equal("00f067aa0ba902b7"))
+		})
+	})
+
+	Describe("StartClient", func() {
+		It("should propagate the span in the outgoing gRPC metadata", func() {
+			ctx, span := tracing.StartClient(context.Background(), "Topic.Publish")
+			defer span.End()
+
+			md, ok := metadata.FromOutgoingContext(ctx)
+			Expect(ok).To(BeTrue())
+			Expect(md.Get("traceparent")).To(HaveLen(1))
+			Expect(md.Get("traceparent")[0]).To(ContainSubstring(span.SpanContext().SpanID().String()))
+		})
+
+		It("should record errors when ended", func() {
+			_, span := tracing.StartClient(context.Background(), "Topic.Publish")
+			tracing.End(span, errors.New("publish failed"))
+
+			Expect(recorder.Ended()).To(HaveLen(1))
+			Expect(recorder.Ended()[0].SpanKind()).To(Equal(trace.SpanKindClient))
+			Expect(recorder.Ended()[0].Status().Code).To(Equal(codes.Error))
+			Expect(recorder.Ended()[0].Status().Description).To(Equal("publish failed"))
+		})
+	})
+})
@@ -17,6 +17,7 @@ package apis
 import (
 	"net/http"
 	"path"
+	"slices"
 	"strings"
 
 	"github.com/nitrictech/go-sdk/internal/handlers"
@@ -28,6 +29,9 @@ import (
 )
 
 // Route providers convenience functions to register a handler in a single method.
+//
+// Middleware is applied from the outermost to innermost: API middleware, group middleware,
+// route middleware (WithRouteMiddleware) and then method middleware (WithMethodMiddleware).
 type Route interface {
 	// All adds a handler for all HTTP methods to the route.
 	All(handler interface{}, opts ...MethodOption)
@@ -52,11 +56,19 @@ type (
 	Middleware = handlers.Middleware[Ctx]
 )
 
+// Chain wraps a route handler in middleware, and Compose combines middleware into one, e.g. to pass several to WithMiddleware.
+// The first middleware is the outermost, so it is called first and returns last.
+var (
+	Chain   = handlers.Chain[Ctx]
+	Compose = handlers.Compose[Ctx]
+)
+
 type route struct {
 	path       string
 	api        *api
+	group      *group
 	manager    *workers.Manager
-	middleware Middleware
+	middleware []Middleware
 }
 
 func (a *api) NewRoute(match string, opts ...RouteOption) Route {
@@ -67,6 +79,7 @@ func (a *api) NewRoute(match string, opts ...RouteOption) Route {
 			path:    path.Join(a.path, match),
 			api:     a,
 		}
+		a.routes[match] = r
 	}
 
 	for _, o := range opts {
@@ -83,16 +96,43 @@ func (r *route) ApiName() string {
 func (r *route) AddMethodHandler(methods []string, handler interface{}, opts ...MethodOption) error {
 	bName := path.Join(r.api.name, r.path, strings.Join(methods, "-"))
 
+	if slices.Contains(methods, http.MethodOptions) {
+		r.api.removePreflight(r.path)
+	}
+
 	// default methodOptions will contain OidcOptions passed to API instance and securityDisabled to false
 	mo := &methodOptions{
 		securityDisabled: false,
 		security:         r.api.security,
 	}
 
+	// routes in a group default to the security settings of the group instead
+	if r.group != nil {
+		mo.security, mo.securityDisabled = r.group.resolveSecurity()
+	}
+
+	// prevent method security options from appending to the shared API or group security slice
+	mo.security = slices.Clip(mo.security)
+
 	for _, o := range opts {
 		o(mo)
 	}
 
+	if !mo.excludeFromSpec {
+		op := &operation{
+			path:     r.path,
+			methods:  methods,
+			reqType:  mo.reqType,
+			respType: mo.respType,
+		}
+
+		if !mo.securityDisabled {
+			op.security = mo.security
+		}
+
+		r.api.operations = append(r.api.operations, op)
+	}
+
 	typedHandler, err := handlers.HandlerFromInterface[Ctx](handler)
 	if err != nil {
 		panic(err)
@@ -123,24 +163,63 @@ func (r *route) AddMethodHandler(methods []string, handler interface{}, opts ...
 		Options: apiOpts,
 	}
 
-	if r.middleware != nil {
-		typedHandler = r.middleware(typedHandler)
+	wkr := newApiWorker(&apiWorkerOpts{
+		Manager:             r.manager,
+		RegistrationRequest: registrationRequest,
+		Handler:             r.applyMiddleware(typedHandler, mo),
+		ErrorHandler:        r.api.errorHandler,
+		Timeout:             mo.timeout,
+		MaxInFlight:         r.api.maxInFlight,
+	})
+
+	r.manager.AddWorker("route:"+bName, wkr)
+
+	if r.api.cors != nil && !slices.Contains(methods, http.MethodOptions) {
+		return r.addPreflight()
 	}
 
-	if r.api.middleware != nil {
-		typedHandler = r.api.middleware(typedHandler)
+	return nil
+}
+
+// addPreflight registers an OPTIONS handler for the route so the Cors middleware can answer preflight requests.
+// The handler is replaced by any OPTIONS handler registered for the route.
+func (r *route) addPreflight() error {
+	if _, ok := r.api.preflights[r.path]; ok || r.api.optionsPaths[r.path] {
+		return nil
 	}
 
-	wkr := newApiWorker(&apiWorkerOpts{
-		RegistrationRequest: registrationRequest,
-		Handler:             typedHandler,
+	err := r.AddMethodHandler([]string{http.MethodOptions}, preflightHandler, WithNoMethodSecurity(), func(mo *methodOptions) {
+		mo.excludeFromSpec = true
 	})
+	if err != nil {
+		return err
+	}
 
-	r.manager.AddWorker("route:"+bName, wkr)
+	// track the preflight handler after registering, as registering an OPTIONS handler marks the path as handled
+	delete(r.api.optionsPaths, r.path)
+	if r.api.preflights == nil {
+		r.api.preflights = map[string]string{}
+	}
+	r.api.preflights[r.path] = "route:" + path.Join(r.api.name, r.path, http.MethodOptions)
 
 	return nil
 }
 
+// applyMiddleware wraps the handler in the API, group, route and method middleware.
+// Middleware is called in that order, with the API middleware being the outermost.
+func (r *route) applyMiddleware(handler Handler, mo *methodOptions) Handler {
+	middlewares := slices.Clone(r.api.middleware)
+
+	if r.group != nil {
+		middlewares = append(middlewares, r.group.allMiddleware()...)
+	}
+
+	middlewares = append(middlewares, r.middleware...)
+	middlewares = append(middlewares, mo.middleware...)
+
+	return handlers.Chain(handler, middlewares...)
+}
+
 func (r *route) All(handler interface{}, opts ...MethodOption) {
 	_ = r.AddMethodHandler([]string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions}, handler, opts...)
 }
@@ -229,6 +308,16 @@ type Api interface {
 	Options(path string, handler interface{}, opts ...MethodOption)
 	// NewRoute creates a new Route object for the given path.
 	NewRoute(path string, opts ...RouteOption) Route
+	// Mount adds a standard net/http Handler for all methods to the path prefix and any paths beneath it.
+	Mount(prefix string, handler http.Handler, opts ...MethodOption)
+	// Group creates a group of routes that share a path prefix, middleware and security settings.
+	//
+	// Middleware is applied from the outermost to innermost: API middleware, group middleware,
+	// route middleware and then method middleware.
+	// The group security settings replace the API security settings for routes in the group.
+	Group(prefix string, opts ...GroupOption) Group
+	// OpenApi generates an OpenAPI 3 document describing the routes registered with the API.
+	OpenApi() *OpenApiDocument
 }
 
 type ApiDetails struct {
@@ -243,7 +332,29 @@ type api struct {
 	securityRules map[string]interface{}
 	security      []OidcOptions
 	path          string
-	middleware    Middleware
+	middleware    []Middleware
+	operations    []*operation
+	openApiPath   string
+	cors          *CorsOptions
+	errorHandler  ErrorHandler
+	maxInFlight   int
+	// names of the workers answering preflight requests, by route path
+	preflights map[string]string
+	// paths with a registered OPTIONS handler
+	optionsPaths map[string]bool
+}
+
+// removePreflight removes the preflight handler for the path, as an OPTIONS handler is being registered for it.
+func (a *api) removePreflight(routePath string) {
+	if a.optionsPaths == nil {
+		a.optionsPaths = map[string]bool{}
+	}
+	a.optionsPaths[routePath] = true
+
+	if name, ok := a.preflights[routePath]; ok {
+		a.manager.RemoveWorker(name)
+		delete(a.preflights, routePath)
+	}
 }
 
 // Get adds a Get method handler to the path with any specified opts.
@@ -251,7 +362,6 @@ func (a *api) Get(match string, handler interface{}, opts ...MethodOption) {
 	r := a.NewRoute(match)
 
 	r.Get(handler, opts...)
-	a.routes[match] = r
 }
 
 // Post adds a Post method handler to the path with any specified opts.
@@ -259,7 +369,6 @@ func (a *api) Post(match string, handler interface{}, opts ...MethodOption) {
 	r := a.NewRoute(match)
 
 	r.Post(handler, opts...)
-	a.routes[match] = r
 }
 
 // Patch adds a Patch method handler to the path with any specified opts.
@@ -267,7 +376,6 @@ func (a *api) Patch(match string, handler interface{}, opts ...MethodOption) {
 	r := a.NewRoute(match)
 
 	r.Patch(handler, opts...)
-	a.routes[match] = r
 }
 
 // Put adds a Put method handler to the path with any specified opts.
@@ -275,7 +383,6 @@ func (a *api) Put(match string, handler interface{}, opts ...MethodOption) {
 	r := a.NewRoute(match)
 
 	r.Put(handler, opts...)
-	a.routes[match] = r
 }
 
 // Delete adds a Delete method handler to the path with any specified opts.
@@ -283,7 +390,6 @@ func (a *api) Delete(match string, handler interface{}, opts ...MethodOption) {
 	r := a.NewRoute(match)
 
 	r.Delete(handler, opts...)
-	a.routes[match] = r
 }
 
 // Options adds an Options method handler to the path with any specified opts.
@@ -291,7 +397,6 @@ func (a *api) Options(match string, handler interface{}, opts ...MethodOption) {
 	r := a.NewRoute(match)
 
 	r.Options(handler, opts...)
-	a.routes[match] = r
 }
 
 // NewApi Registers a new API Resource.
@@ -299,9 +404,10 @@ func (a *api) Options(match string, handler interface{}, opts ...MethodOption) {
 // The returned API object can be used to register Routes and Methods, with Handlers.
 func NewApi(name string, opts ...ApiOption) Api {
 	a := &api{
-		name:    name,
-		routes:  map[string]Route{},
-		manager: workers.GetDefaultManager(),
+		name:       name,
+		routes:     map[string]Route{},
+		manager:    workers.GetDefaultManager(),
+		preflights: map[string]string{},
 	}
 
 	// Apply options
@@ -341,5 +447,13 @@ func NewApi(name string, opts ...ApiOption) Api {
 		panic(result.Err)
 	}
 
+	if a.openApiPath != "" {
+		a.Get(a.openApiPath, func(ctx *Ctx) error {
+			return ctx.JSON(http.StatusOK, a.OpenApi())
+		}, WithNoMethodSecurity(), func(mo *methodOptions) {
+			mo.excludeFromSpec = true
+		})
+	}
+
 	return a
 }
@@ -0,0 +1,98 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apis
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/nitrictech/go-sdk/nitric/workers"
+)
+
+var _ = Describe("Route", func() {
+	var calls []string
+
+	recordingMiddleware := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx *Ctx) error {
+				calls = append(calls, name)
+				return next(ctx)
+			}
+		}
+	}
+
+	BeforeEach(func() {
+		calls = []string{}
+	})
+
+	Describe("applyMiddleware()", func() {
+		It("should call API, group, route and method middleware in order", func() {
+			a := &api{
+				name:       "test-api",
+				routes:     map[string]Route{},
+				manager:    workers.New(),
+				middleware: []Middleware{recordingMiddleware("api")},
+			}
+
+			g := a.Group("/admin", WithGroupMiddleware(recordingMiddleware("group")))
+			r := g.NewRoute("/users", WithRouteMiddleware(recordingMiddleware("route"))).(*route)
+
+			mo := &methodOptions{}
+			WithMethodMiddleware(recordingMiddleware("method"))(mo)
+
+			handler := r.applyMiddleware(func(ctx *Ctx) error {
+				calls = append(calls, "handler")
+				return nil
+			}, mo)
+
+			Expect(handler(newTestCtx("", nil))).To(Succeed())
+			Expect(calls).To(Equal([]string{"api", "group", "route", "method", "handler"}))
+		})
+	})
+
+	Describe("NewRoute()", func() {
+		It("should keep the route's middleware when methods are added to the path", func() {
+			a := &api{
+				name:    "test-api",
+				routes:  map[string]Route{},
+				manager: workers.New(),
+			}
+
+			a.NewRoute("/users", WithRouteMiddleware(recordingMiddleware("route")))
+			a.Get("/users", func(ctx *Ctx) {})
+
+			r := a.NewRoute("/users").(*route)
+			handler := r.applyMiddleware(func(ctx *Ctx) error {
+				calls = append(calls, "handler")
+				return nil
+			}, &methodOptions{})
+
+			Expect(handler(newTestCtx("", nil))).To(Succeed())
+			Expect(calls).To(Equal([]string{"route", "handler"}))
+		})
+	})
+
+	Describe("Chain()", func() {
+		It("should call composed middleware in the order provided, outermost first", func() {
+			handler := Chain(func(ctx *Ctx) error {
+				calls = append(calls, "handler")
+				return nil
+			}, Compose(recordingMiddleware("auth"), recordingMiddleware("logging")), recordingMiddleware("route"))
+
+			Expect(handler(newTestCtx("", nil))).To(Succeed())
+			Expect(calls).To(Equal([]string{"auth", "logging", "route", "handler"}))
+		})
+	})
+})
@@ -15,16 +15,24 @@
 package apis
 
 import (
+	"encoding/json"
+	"mime"
+	"net/http"
 	"net/textproto"
+	"strings"
 
+	"github.com/nitrictech/go-sdk/internal/handlers"
 	apispb "github.com/nitrictech/nitric/core/pkg/proto/apis/v1"
 )
 
 type Ctx struct {
-	id       string
-	Request  Request
-	Response *Response
-	Extras   map[string]interface{}
+	handlers.RequestContext
+
+	id           string
+	Request      Request
+	Response     *Response
+	Extras       map[string]interface{}
+	errorHandler ErrorHandler
 }
 
 func (c *Ctx) ToClientMessage() *apispb.ClientMessage {
@@ -78,12 +86,106 @@ func NewCtx(msg *apispb.ServerMessage) *Ctx {
 	}
 }
 
+// WithError replaces the response status and body with RFC 7807 problem details for the given error.
+// The status is determined by HttpStatusFromError and the problem can be customized using WithErrorHandler.
+// Response headers already set, such as CORS headers set by middleware, are preserved.
 func (c *Ctx) WithError(err error) {
-	c.Response = &Response{
-		Status: 500,
-		Headers: map[string][]string{
-			"Content-Type": {"text/plain"},
-		},
-		Body: []byte("Internal Server Error"),
+	problem := newProblem(err)
+
+	if c.errorHandler != nil {
+		c.errorHandler(c, err, problem)
 	}
+
+	body, marshalErr := json.Marshal(problem)
+	if marshalErr != nil {
+		c.Bytes(http.StatusInternalServerError, "text/plain", []byte("Internal Server Error"))
+		return
+	}
+
+	c.Bytes(problem.Status, "application/problem+json", body)
+}
+
+// Bind decodes the request body into v based on the request Content-Type.
+//
+// JSON is assumed when no Content-Type is provided. An HttpError with status 415 is returned
+// for unsupported content types and 400 for empty or malformed bodies, when returned from a
+// handler these are written to the response with the matching status.
+func (c *Ctx) Bind(v interface{}) error {
+	contentType := headerValue(c.Request.Headers(), "Content-Type")
+	if contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			return NewHttpErrorWithCause(http.StatusUnsupportedMediaType, "", err)
+		}
+
+		if mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json") {
+			return NewHttpError(http.StatusUnsupportedMediaType, "unsupported content type "+mediaType)
+		}
+	}
+
+	return c.BindJSON(v)
+}
+
+// BindJSON decodes the JSON request body into v, regardless of the request Content-Type.
+func (c *Ctx) BindJSON(v interface{}) error {
+	data := c.Request.Data()
+	if len(data) == 0 {
+		return NewHttpError(http.StatusBadRequest, "request body is empty")
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return NewHttpErrorWithCause(http.StatusBadRequest, "malformed JSON request body", err)
+	}
+
+	return nil
+}
+
+// JSON writes v as the JSON response body with the given status code.
+func (c *Ctx) JSON(status int, v interface{}) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	c.Bytes(status, "application/json", body)
+
+	return nil
+}
+
+// Text writes body as the plain text response body with the given status code.
+func (c *Ctx) Text(status int, body string) {
+	c.Bytes(status, "text/plain; charset=utf-8", []byte(body))
+}
+
+// Bytes writes body as the response body with the given status code and content type.
+func (c *Ctx) Bytes(status int, contentType string, body []byte) {
+	if c.Response == nil {
+		c.Response = &Response{}
+	}
+
+	if c.Response.Headers == nil {
+		c.Response.Headers = map[string][]string{}
+	}
+
+	c.Response.Status = status
+	c.Response.Headers["Content-Type"] = []string{contentType}
+	c.Response.Body = body
+
+	// the body has changed, so any content length set previously no longer applies
+	delete(c.Response.Headers, "Content-Length")
+}
+
+// headerValue returns the first value of the named header, ignoring the casing of the header names.
+func headerValue(headers textproto.MIMEHeader, name string) string {
+	if v := headers.Get(name); v != "" {
+		return v
+	}
+
+	for k, v := range headers {
+		if strings.EqualFold(k, name) && len(v) > 0 {
+			return v[0]
+		}
+	}
+
+	return ""
 }
@@ -0,0 +1,215 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apis
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	apierrors "github.com/nitrictech/go-sdk/nitric/errors"
+	"github.com/nitrictech/go-sdk/nitric/errors/codes"
+	apispb "github.com/nitrictech/nitric/core/pkg/proto/apis/v1"
+)
+
+func newTestCtx(contentType string, body []byte) *Ctx {
+	headers := map[string]*apispb.HeaderValue{}
+	if contentType != "" {
+		headers["content-type"] = &apispb.HeaderValue{Value: []string{contentType}}
+	}
+
+	return NewCtx(&apispb.ServerMessage{
+		Id: "test",
+		Content: &apispb.ServerMessage_HttpRequest{
+			HttpRequest: &apispb.HttpRequest{
+				Method:  "POST",
+				Path:    "/test",
+				Headers: headers,
+				Body:    body,
+			},
+		},
+	})
+}
+
+// statusOnlyError sets the HTTP status of the response without implementing HttpDetailError.
+type statusOnlyError struct {
+	status int
+	err    error
+}
+
+func (s *statusOnlyError) Error() string {
+	return s.err.Error()
+}
+
+func (s *statusOnlyError) HTTPStatus() int {
+	return s.status
+}
+
+var _ = Describe("Ctx", func() {
+	type payload struct {
+		Name string `json:"name"`
+	}
+
+	Describe("Bind()", func() {
+		When("the body is valid JSON", func() {
+			It("should decode the body", func() {
+				ctx := newTestCtx("application/json; charset=utf-8", []byte(`{"name":"test"}`))
+
+				p := payload{}
+				err := ctx.Bind(&p)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(p.Name).To(Equal("test"))
+			})
+		})
+
+		When("no content type is provided", func() {
+			It("should decode the body as JSON", func() {
+				ctx := newTestCtx("", []byte(`{"name":"test"}`))
+
+				p := payload{}
+				Expect(ctx.Bind(&p)).To(Succeed())
+				Expect(p.Name).To(Equal("test"))
+			})
+		})
+
+		When("the body is malformed", func() {
+			It("should return a 400 error", func() {
+				ctx := newTestCtx("application/json", []byte(`{"name":`))
+
+				err := ctx.Bind(&payload{})
+
+				var httpErr *HttpError
+				Expect(errors.As(err, &httpErr)).To(BeTrue())
+				Expect(httpErr.Status).To(Equal(400))
+			})
+		})
+
+		When("the content type is not supported", func() {
+			It("should return a 415 error", func() {
+				ctx := newTestCtx("text/csv", []byte(`name\ntest`))
+
+				err := ctx.Bind(&payload{})
+
+				var httpErr *HttpError
+				Expect(errors.As(err, &httpErr)).To(BeTrue())
+				Expect(httpErr.Status).To(Equal(415))
+			})
+		})
+	})
+
+	Describe("JSON()", func() {
+		It("should write the JSON body, status and content type", func() {
+			ctx := newTestCtx("", nil)
+
+			err := ctx.JSON(201, payload{Name: "test"})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ctx.Response.Status).To(Equal(201))
+			Expect(ctx.Response.Headers["Content-Type"]).To(Equal([]string{"application/json"}))
+			Expect(string(ctx.Response.Body)).To(Equal(`{"name":"test"}`))
+		})
+	})
+
+	Describe("WithError()", func() {
+		When("the error is an HttpError", func() {
+			It("should respond with the error status", func() {
+				ctx := newTestCtx("", nil)
+
+				ctx.WithError(NewHttpError(415, ""))
+
+				Expect(ctx.Response.Status).To(Equal(415))
+				Expect(ctx.Response.Headers["Content-Type"]).To(Equal([]string{"application/problem+json"}))
+				Expect(ctx.Response.Body).To(MatchJSON(`{"type":"about:blank","title":"Unsupported Media Type","status":415,"detail":"Unsupported Media Type"}`))
+			})
+		})
+
+		When("the error is a nitric error", func() {
+			It("should map the error code to a status", func() {
+				ctx := newTestCtx("", nil)
+
+				ctx.WithError(apierrors.New(codes.NotFound, "customer not found"))
+
+				Expect(ctx.Response.Status).To(Equal(404))
+				Expect(ctx.Response.Body).To(MatchJSON(`{"type":"about:blank","title":"Not Found","status":404,"detail":"customer not found"}`))
+			})
+		})
+
+		When("the error has a cause", func() {
+			It("should not include the cause in the details", func() {
+				ctx := newTestCtx("", nil)
+
+				ctx.WithError(NewHttpErrorWithCause(409, "customer already exists", errors.New("duplicate key in customers_pkey")))
+
+				Expect(ctx.Response.Status).To(Equal(409))
+				Expect(ctx.Response.Body).To(MatchJSON(`{"type":"about:blank","title":"Conflict","status":409,"detail":"customer already exists"}`))
+			})
+		})
+
+		When("the error sets its own status without a detail", func() {
+			It("should respond with the status without details", func() {
+				ctx := newTestCtx("", nil)
+
+				ctx.WithError(&statusOnlyError{status: 429, err: errors.New("rate limit bucket tenant-42 exhausted")})
+
+				Expect(ctx.Response.Status).To(Equal(429))
+				Expect(ctx.Response.Body).To(MatchJSON(`{"type":"about:blank","title":"Too Many Requests","status":429}`))
+			})
+		})
+
+		When("the error is not an HttpError", func() {
+			It("should respond with an internal server error without details", func() {
+				ctx := newTestCtx("", nil)
+
+				ctx.WithError(errors.New("boom"))
+
+				Expect(ctx.Response.Status).To(Equal(500))
+				Expect(ctx.Response.Body).To(MatchJSON(`{"type":"about:blank","title":"Internal Server Error","status":500}`))
+			})
+		})
+
+		When("an error handler is set", func() {
+			It("should allow the problem to be customized", func() {
+				ctx := newTestCtx("", nil)
+				ctx.errorHandler = func(ctx *Ctx, err error, problem *Problem) {
+					problem.Detail = err.Error()
+					problem.Extensions = map[string]interface{}{"requestId": "test"}
+				}
+
+				ctx.WithError(errors.New("boom"))
+
+				Expect(ctx.Response.Status).To(Equal(500))
+				Expect(ctx.Response.Body).To(MatchJSON(`{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"boom","requestId":"test"}`))
+			})
+		})
+	})
+
+	Describe("HttpStatusFromError()", func() {
+		It("should map nitric error codes to HTTP statuses", func() {
+			Expect(HttpStatusFromError(apierrors.New(codes.InvalidArgument, ""))).To(Equal(400))
+			Expect(HttpStatusFromError(apierrors.New(codes.Unauthenticated, ""))).To(Equal(401))
+			Expect(HttpStatusFromError(apierrors.New(codes.PermissionDenied, ""))).To(Equal(403))
+			Expect(HttpStatusFromError(apierrors.New(codes.AlreadyExists, ""))).To(Equal(409))
+			Expect(HttpStatusFromError(apierrors.New(codes.Unavailable, ""))).To(Equal(503))
+		})
+
+		It("should use the status of wrapped HttpErrors", func() {
+			err := apierrors.NewWithCause(codes.Internal, "wrapped", NewHttpError(418, ""))
+
+			Expect(HttpStatusFromError(err)).To(Equal(418))
+		})
+	})
+})
@@ -0,0 +1,147 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apis
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var defaultCorsMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+}
+
+type CorsOptions struct {
+	// AllowOrigins is the list of origins that may make cross-origin requests.
+	// Origins may contain a single wildcard e.g. 'https://*.example.com', or be '*' to allow any origin.
+	AllowOrigins []string
+	// AllowMethods is the list of methods allowed in cross-origin requests, defaults to the simple methods and PUT, PATCH and DELETE.
+	AllowMethods []string
+	// AllowHeaders is the list of headers allowed in cross-origin requests, defaults to the headers requested in preflight requests.
+	AllowHeaders []string
+	// ExposeHeaders is the list of response headers made available to the client.
+	ExposeHeaders []string
+	// AllowCredentials allows requests to include cookies and HTTP authentication.
+	AllowCredentials bool
+	// MaxAge is how long the results of a preflight request can be cached for.
+	MaxAge time.Duration
+}
+
+func (c CorsOptions) isAllowedOrigin(origin string) bool {
+	for _, allowed := range c.AllowOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+
+		if prefix, suffix, ok := strings.Cut(allowed, "*"); ok {
+			if len(origin) >= len(prefix)+len(suffix) && strings.HasPrefix(origin, prefix) && strings.HasSuffix(origin, suffix) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Cors creates a middleware that sets the CORS response headers and answers preflight requests.
+//
+// Preflight requests are only answered for registered routes with an OPTIONS handler,
+// use WithCors to register OPTIONS handlers for every route in an API automatically.
+func Cors(opts CorsOptions) Middleware {
+	allowMethods := opts.AllowMethods
+	if len(allowMethods) == 0 {
+		allowMethods = defaultCorsMethods
+	}
+
+	allowAnyOrigin := slices.Contains(opts.AllowOrigins, "*")
+
+	return func(next Handler) Handler {
+		return func(ctx *Ctx) error {
+			origin := headerValue(ctx.Request.Headers(), "Origin")
+			requestMethod := headerValue(ctx.Request.Headers(), "Access-Control-Request-Method")
+			isPreflight := ctx.Request.Method() == http.MethodOptions && requestMethod != ""
+
+			if ctx.Response.Headers == nil {
+				ctx.Response.Headers = map[string][]string{}
+			}
+			headers := http.Header(ctx.Response.Headers)
+
+			if !allowAnyOrigin || opts.AllowCredentials {
+				headers.Add("Vary", "Origin")
+			}
+
+			if isPreflight {
+				headers.Add("Vary", "Access-Control-Request-Method")
+				headers.Add("Vary", "Access-Control-Request-Headers")
+				ctx.Response.Status = http.StatusNoContent
+			}
+
+			if origin == "" || !opts.isAllowedOrigin(origin) {
+				if isPreflight {
+					return nil
+				}
+
+				return next(ctx)
+			}
+
+			if allowAnyOrigin && !opts.AllowCredentials {
+				headers.Set("Access-Control-Allow-Origin", "*")
+			} else {
+				headers.Set("Access-Control-Allow-Origin", origin)
+			}
+
+			if opts.AllowCredentials {
+				headers.Set("Access-Control-Allow-Credentials", "true")
+			}
+
+			if !isPreflight {
+				if len(opts.ExposeHeaders) > 0 {
+					headers.Set("Access-Control-Expose-Headers", strings.Join(opts.ExposeHeaders, ", "))
+				}
+
+				return next(ctx)
+			}
+
+			headers.Set("Access-Control-Allow-Methods", strings.Join(allowMethods, ", "))
+
+			if len(opts.AllowHeaders) > 0 {
+				headers.Set("Access-Control-Allow-Headers", strings.Join(opts.AllowHeaders, ", "))
+			} else if requestHeaders := headerValue(ctx.Request.Headers(), "Access-Control-Request-Headers"); requestHeaders != "" {
+				headers.Set("Access-Control-Allow-Headers", requestHeaders)
+			}
+
+			if opts.MaxAge > 0 {
+				headers.Set("Access-Control-Max-Age", strconv.Itoa(int(opts.MaxAge.Seconds())))
+			}
+
+			return nil
+		}
+	}
+}
+
+// preflightHandler handles OPTIONS requests for routes without an OPTIONS handler, preflight requests are answered by the Cors middleware.
+func preflightHandler(ctx *Ctx) error {
+	ctx.Response.Status = http.StatusNoContent
+
+	return nil
+}
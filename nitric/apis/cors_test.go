@@ -0,0 +1,159 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apis
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/nitrictech/go-sdk/nitric/workers"
+	apispb "github.com/nitrictech/nitric/core/pkg/proto/apis/v1"
+)
+
+func newCorsCtx(method string, headers map[string]string) *Ctx {
+	reqHeaders := map[string]*apispb.HeaderValue{}
+	for k, v := range headers {
+		reqHeaders[k] = &apispb.HeaderValue{Value: []string{v}}
+	}
+
+	return NewCtx(&apispb.ServerMessage{
+		Id: "test",
+		Content: &apispb.ServerMessage_HttpRequest{
+			HttpRequest: &apispb.HttpRequest{
+				Method:  method,
+				Path:    "/test",
+				Headers: reqHeaders,
+			},
+		},
+	})
+}
+
+var _ = Describe("Cors", func() {
+	var (
+		called  bool
+		handler Handler
+	)
+
+	BeforeEach(func() {
+		called = false
+		handler = Cors(CorsOptions{
+			AllowOrigins:     []string{"https://*.example.com"},
+			AllowHeaders:     []string{"Authorization"},
+			ExposeHeaders:    []string{"X-Request-Id"},
+			AllowCredentials: true,
+			MaxAge:           time.Hour,
+		})(func(ctx *Ctx) error {
+			called = true
+			return nil
+		})
+	})
+
+	When("a preflight request is received from an allowed origin", func() {
+		It("should answer the preflight request", func() {
+			ctx := newCorsCtx(http.MethodOptions, map[string]string{
+				"origin":                        "https://app.example.com",
+				"access-control-request-method": "POST",
+			})
+
+			Expect(handler(ctx)).To(Succeed())
+
+			headers := http.Header(ctx.Response.Headers)
+			Expect(called).To(BeFalse())
+			Expect(ctx.Response.Status).To(Equal(http.StatusNoContent))
+			Expect(headers.Get("Access-Control-Allow-Origin")).To(Equal("https://app.example.com"))
+			Expect(headers.Get("Access-Control-Allow-Methods")).To(ContainSubstring("POST"))
+			Expect(headers.Get("Access-Control-Allow-Headers")).To(Equal("Authorization"))
+			Expect(headers.Get("Access-Control-Allow-Credentials")).To(Equal("true"))
+			Expect(headers.Get("Access-Control-Max-Age")).To(Equal("3600"))
+		})
+	})
+
+	When("a request is received from an allowed origin", func() {
+		It("should call the handler and set the CORS headers", func() {
+			ctx := newCorsCtx(http.MethodGet, map[string]string{
+				"origin": "https://app.example.com",
+			})
+
+			Expect(handler(ctx)).To(Succeed())
+
+			headers := http.Header(ctx.Response.Headers)
+			Expect(called).To(BeTrue())
+			Expect(headers.Get("Access-Control-Allow-Origin")).To(Equal("https://app.example.com"))
+			Expect(headers.Get("Access-Control-Expose-Headers")).To(Equal("X-Request-Id"))
+			Expect(headers.Values("Vary")).To(ContainElement("Origin"))
+		})
+
+		It("should preserve the CORS headers when the handler fails", func() {
+			ctx := newCorsCtx(http.MethodGet, map[string]string{
+				"origin": "https://app.example.com",
+			})
+
+			err := Cors(CorsOptions{AllowOrigins: []string{"*"}})(func(ctx *Ctx) error {
+				return errors.New("boom")
+			})(ctx)
+			ctx.WithError(err)
+
+			Expect(ctx.Response.Status).To(Equal(http.StatusInternalServerError))
+			Expect(http.Header(ctx.Response.Headers).Get("Access-Control-Allow-Origin")).To(Equal("*"))
+		})
+	})
+
+	When("a request is received from a disallowed origin", func() {
+		It("should not set the CORS headers", func() {
+			ctx := newCorsCtx(http.MethodGet, map[string]string{
+				"origin": "https://example.org",
+			})
+
+			Expect(handler(ctx)).To(Succeed())
+
+			Expect(called).To(BeTrue())
+			Expect(http.Header(ctx.Response.Headers).Get("Access-Control-Allow-Origin")).To(BeEmpty())
+		})
+	})
+})
+
+var _ = Describe("WithCors", func() {
+	var a *api
+
+	BeforeEach(func() {
+		a = &api{
+			name:    "test-api",
+			routes:  map[string]Route{},
+			manager: workers.New(),
+		}
+		WithCors(CorsOptions{AllowOrigins: []string{"*"}})(a)
+	})
+
+	It("should register preflight handlers for routes without an OPTIONS handler", func() {
+		a.Get("/customers", func(ctx *Ctx) {})
+		a.Post("/customers", func(ctx *Ctx) {})
+
+		Expect(a.preflights).To(Equal(map[string]string{
+			"/customers": "route:test-api/customers/OPTIONS",
+		}))
+	})
+
+	It("should replace the preflight handler when an OPTIONS handler is registered", func() {
+		a.Get("/customers", func(ctx *Ctx) {})
+		a.NewRoute("/customers").All(func(ctx *Ctx) {})
+
+		Expect(a.preflights).To(BeEmpty())
+		Expect(a.optionsPaths).To(HaveKey("/customers"))
+	})
+})
@@ -0,0 +1,193 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	apierrors "github.com/nitrictech/go-sdk/nitric/errors"
+	"github.com/nitrictech/go-sdk/nitric/errors/codes"
+)
+
+// HttpStatusError is implemented by errors that determine the HTTP status returned to the caller.
+type HttpStatusError interface {
+	error
+	HTTPStatus() int
+}
+
+// HttpDetailError is implemented by errors that set the detail of the problem returned to the caller.
+// The detail of errors with a status below 500 that don't implement it is left empty, so their causes aren't exposed.
+type HttpDetailError interface {
+	error
+	Detail() string
+}
+
+// HttpError is an error that carries the HTTP status code to return to the caller.
+type HttpError struct {
+	Status  int
+	Message string
+	cause   error
+}
+
+func (h *HttpError) Error() string {
+	if h.cause != nil {
+		return fmt.Sprintf("%d %s: %s", h.Status, h.Message, h.cause.Error())
+	}
+
+	return fmt.Sprintf("%d %s", h.Status, h.Message)
+}
+
+func (h *HttpError) Unwrap() error {
+	return h.cause
+}
+
+func (h *HttpError) HTTPStatus() int {
+	return h.Status
+}
+
+// Detail returns the message of the error without its cause, which is only included in Error() for logging.
+func (h *HttpError) Detail() string {
+	return h.Message
+}
+
+// NewHttpError - Creates a new error that will be returned to the caller with the given status code
+func NewHttpError(status int, message string) *HttpError {
+	if message == "" {
+		message = http.StatusText(status)
+	}
+
+	return &HttpError{
+		Status:  status,
+		Message: message,
+	}
+}
+
+// NewHttpErrorWithCause - Creates a new HttpError with the given error as it's cause
+func NewHttpErrorWithCause(status int, message string, cause error) *HttpError {
+	httpErr := NewHttpError(status, message)
+	httpErr.cause = cause
+
+	return httpErr
+}
+
+// HttpStatusFromError returns the HTTP status for an error returned from a handler.
+//
+// Errors implementing HttpStatusError return their own status, errors created by the nitric errors
+// package are mapped from their code e.g. codes.NotFound to 404, and all other errors return 500.
+func HttpStatusFromError(err error) int {
+	var statusErr HttpStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.HTTPStatus()
+	}
+
+	return httpStatusFromCode(apierrors.Code(err))
+}
+
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Cancelled:
+		// non-standard status used by nginx and gRPC gateways for client closed requests
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// Problem is an RFC 7807 problem details response body.
+type Problem struct {
+	// Type is a URI reference identifying the problem type, defaults to 'about:blank'.
+	Type string `json:"type,omitempty"`
+	// Title is a short summary of the problem type, defaults to the text of the status code.
+	Title string `json:"title,omitempty"`
+	// Status is the HTTP status code of the response.
+	Status int `json:"status"`
+	// Detail is an explanation specific to this occurrence of the problem.
+	// Omitted for server errors by default, to avoid leaking internal details.
+	Detail string `json:"detail,omitempty"`
+	// Instance is a URI reference identifying this occurrence of the problem.
+	Instance string `json:"instance,omitempty"`
+	// Extensions are additional members included in the problem details.
+	Extensions map[string]interface{} `json:"-"`
+}
+
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	type problem Problem
+
+	members := map[string]interface{}{}
+	for k, v := range p.Extensions {
+		members[k] = v
+	}
+
+	body, err := json.Marshal((*problem)(p))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(body, &members); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(members)
+}
+
+// ErrorHandler customizes the problem details returned for a handler error, e.g. to add or redact details.
+type ErrorHandler func(ctx *Ctx, err error, problem *Problem)
+
+func newProblem(err error) *Problem {
+	status := HttpStatusFromError(err)
+
+	problem := &Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+	}
+
+	if status < http.StatusInternalServerError {
+		var detailErr HttpDetailError
+		var apiErr *apierrors.ApiError
+
+		switch {
+		case errors.As(err, &detailErr):
+			problem.Detail = detailErr.Detail()
+		case errors.As(err, &apiErr):
+			problem.Detail = apiErr.Message()
+		}
+	}
+
+	return problem
+}
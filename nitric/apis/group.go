@@ -0,0 +1,168 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apis
+
+import (
+	"net/http"
+	"path"
+	"slices"
+)
+
+// Group is a set of routes within an API that share a path prefix, middleware and security settings.
+type Group interface {
+	// Get adds a Get method handler to the path, relative to the group prefix, with any specified opts.
+	Get(path string, handler interface{}, opts ...MethodOption)
+	// Put adds a Put method handler to the path, relative to the group prefix, with any specified opts.
+	Put(path string, handler interface{}, opts ...MethodOption)
+	// Patch adds a Patch method handler to the path, relative to the group prefix, with any specified opts.
+	Patch(path string, handler interface{}, opts ...MethodOption)
+	// Post adds a Post method handler to the path, relative to the group prefix, with any specified opts.
+	Post(path string, handler interface{}, opts ...MethodOption)
+	// Delete adds a Delete method handler to the path, relative to the group prefix, with any specified opts.
+	Delete(path string, handler interface{}, opts ...MethodOption)
+	// Options adds a Options method handler to the path, relative to the group prefix, with any specified opts.
+	Options(path string, handler interface{}, opts ...MethodOption)
+	// NewRoute creates a new Route object for the path, relative to the group prefix.
+	NewRoute(path string, opts ...RouteOption) Route
+	// Mount adds a standard net/http Handler for all methods to the path prefix and any paths beneath it, relative to the group prefix.
+	Mount(prefix string, handler http.Handler, opts ...MethodOption)
+	// Group creates a nested group, with a path prefix relative to this group's prefix.
+	// The nested group inherits the middleware and security settings of this group.
+	Group(prefix string, opts ...GroupOption) Group
+}
+
+type group struct {
+	api    *api
+	parent *group
+	routes map[string]Route
+	// path of the group, relative to the API base path
+	path       string
+	middleware []Middleware
+	// security overrides the security settings of the parent group or API when securitySet is true
+	security         []OidcOptions
+	securityDisabled bool
+	securitySet      bool
+}
+
+var _ Group = (*group)(nil)
+
+func newGroup(a *api, parent *group, prefix string, opts ...GroupOption) *group {
+	g := &group{
+		api:    a,
+		parent: parent,
+		routes: map[string]Route{},
+		path:   prefix,
+	}
+
+	if parent != nil {
+		g.path = path.Join(parent.path, prefix)
+	}
+
+	for _, o := range opts {
+		o(g)
+	}
+
+	return g
+}
+
+// Group creates a group of routes that share the path prefix, and the middleware and security settings from opts.
+func (a *api) Group(prefix string, opts ...GroupOption) Group {
+	return newGroup(a, nil, prefix, opts...)
+}
+
+func (g *group) Group(prefix string, opts ...GroupOption) Group {
+	return newGroup(g.api, g, prefix, opts...)
+}
+
+func (g *group) NewRoute(match string, opts ...RouteOption) Route {
+	r, ok := g.routes[match]
+	if !ok {
+		r = &route{
+			manager: g.api.manager,
+			path:    path.Join(g.api.path, g.path, match),
+			api:     g.api,
+			group:   g,
+		}
+		g.routes[match] = r
+	}
+
+	for _, o := range opts {
+		o(r.(*route))
+	}
+
+	return r
+}
+
+// resolveSecurity returns the security settings of the closest group that overrides them, falling back to the API security.
+func (g *group) resolveSecurity() ([]OidcOptions, bool) {
+	for cur := g; cur != nil; cur = cur.parent {
+		if cur.securitySet {
+			return cur.security, cur.securityDisabled
+		}
+	}
+
+	return g.api.security, false
+}
+
+// allMiddleware returns the middleware of this group and its parents, with the outermost group's middleware first.
+func (g *group) allMiddleware() []Middleware {
+	middlewares := []Middleware{}
+
+	for cur := g; cur != nil; cur = cur.parent {
+		middlewares = append(slices.Clone(cur.middleware), middlewares...)
+	}
+
+	return middlewares
+}
+
+func (g *group) Get(match string, handler interface{}, opts ...MethodOption) {
+	r := g.NewRoute(match)
+
+	r.Get(handler, opts...)
+}
+
+func (g *group) Post(match string, handler interface{}, opts ...MethodOption) {
+	r := g.NewRoute(match)
+
+	r.Post(handler, opts...)
+}
+
+func (g *group) Patch(match string, handler interface{}, opts ...MethodOption) {
+	r := g.NewRoute(match)
+
+	r.Patch(handler, opts...)
+}
+
+func (g *group) Put(match string, handler interface{}, opts ...MethodOption) {
+	r := g.NewRoute(match)
+
+	r.Put(handler, opts...)
+}
+
+func (g *group) Delete(match string, handler interface{}, opts ...MethodOption) {
+	r := g.NewRoute(match)
+
+	r.Delete(handler, opts...)
+}
+
+func (g *group) Options(match string, handler interface{}, opts ...MethodOption) {
+	r := g.NewRoute(match)
+
+	r.Options(handler, opts...)
+}
+
+func (g *group) Mount(prefix string, handler http.Handler, opts ...MethodOption) {
+	mount(g.NewRoute, prefix, handler, opts...)
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apis
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/nitrictech/go-sdk/internal/handlers"
+	"github.com/nitrictech/go-sdk/nitric/workers"
+)
+
+var _ = Describe("Group", func() {
+	var (
+		a     *api
+		calls []string
+	)
+
+	recordingMiddleware := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx *Ctx) error {
+				calls = append(calls, name)
+				return next(ctx)
+			}
+		}
+	}
+
+	BeforeEach(func() {
+		calls = []string{}
+		a = &api{
+			name:     "test-api",
+			routes:   map[string]Route{},
+			manager:  workers.New(),
+			path:     "/v1",
+			security: []OidcOptions{{Name: "api-rule"}},
+		}
+	})
+
+	When("groups are nested", func() {
+		It("should join the path prefixes", func() {
+			admin := a.Group("/admin")
+			users := admin.Group("/users")
+
+			r := users.NewRoute("/:id").(*route)
+
+			Expect(r.path).To(Equal("/v1/admin/users/:id"))
+		})
+
+		It("should apply middleware from the outermost group first", func() {
+			admin := a.Group("/admin", WithGroupMiddleware(recordingMiddleware("admin")))
+			users := admin.Group("/users", WithGroupMiddleware(recordingMiddleware("users")))
+
+			handler := handlers.Chain(func(ctx *Ctx) error {
+				calls = append(calls, "handler")
+				return nil
+			}, users.(*group).allMiddleware()...)
+
+			Expect(handler(newTestCtx("", nil))).To(Succeed())
+			Expect(calls).To(Equal([]string{"admin", "users", "handler"}))
+		})
+	})
+
+	Describe("security", func() {
+		It("should default to the API security", func() {
+			security, disabled := a.Group("/public").(*group).resolveSecurity()
+
+			Expect(disabled).To(BeFalse())
+			Expect(security).To(Equal(a.security))
+		})
+
+		It("should inherit the closest group security", func() {
+			admin := a.Group("/admin", WithGroupSecurity(OidcOptions{Name: "admin-rule"}))
+			users := admin.Group("/users")
+
+			security, disabled := users.(*group).resolveSecurity()
+
+			Expect(disabled).To(BeFalse())
+			Expect(security).To(Equal([]OidcOptions{{Name: "admin-rule"}}))
+		})
+
+		It("should allow nested groups to disable security", func() {
+			admin := a.Group("/admin", WithGroupSecurity(OidcOptions{Name: "admin-rule"}))
+			health := admin.Group("/health", WithNoGroupSecurity())
+
+			_, disabled := health.(*group).resolveSecurity()
+
+			Expect(disabled).To(BeTrue())
+		})
+	})
+})
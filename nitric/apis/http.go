@@ -0,0 +1,162 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apis
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"path"
+)
+
+// HttpHandler adapts a standard net/http Handler to a Handler.
+//
+// The request path is passed through unchanged, use http.StripPrefix to remove a mount prefix if required.
+// Response headers already set, such as CORS headers set by middleware, are visible to the handler and kept unless it replaces them.
+func HttpHandler(handler http.Handler) Handler {
+	return func(ctx *Ctx) error {
+		req, err := ctx.toHttpRequest(ctx.Context())
+		if err != nil {
+			return err
+		}
+
+		if ctx.Response == nil {
+			ctx.Response = &Response{}
+		}
+
+		w := newResponseWriter(ctx.Response.Headers)
+		handler.ServeHTTP(w, req)
+
+		w.writeTo(ctx.Response)
+
+		return nil
+	}
+}
+
+// Mount registers a standard net/http Handler for all methods on the prefix and any paths beneath it.
+func (a *api) Mount(prefix string, handler http.Handler, opts ...MethodOption) {
+	mount(a.NewRoute, prefix, handler, opts...)
+}
+
+// mount registers the handler for all methods on the prefix and any paths beneath it, using newRoute to create the routes.
+func mount(newRoute func(match string, opts ...RouteOption) Route, prefix string, handler http.Handler, opts ...MethodOption) {
+	h := HttpHandler(handler)
+
+	for _, match := range []string{prefix, path.Join(prefix, "*")} {
+		r := newRoute(match)
+
+		r.All(h, opts...)
+	}
+}
+
+// toHttpRequest converts the request to a standard net/http Request.
+func (c *Ctx) toHttpRequest(ctx context.Context) (*http.Request, error) {
+	u := &url.URL{
+		Path:     c.Request.Path(),
+		RawQuery: url.Values(c.Request.Query()).Encode(),
+	}
+
+	body := c.Request.Data()
+
+	req, err := http.NewRequestWithContext(ctx, c.Request.Method(), u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, NewHttpErrorWithCause(http.StatusBadRequest, "", err)
+	}
+
+	for k, v := range c.Request.Headers() {
+		req.Header[textproto.CanonicalMIMEHeaderKey(k)] = v
+	}
+
+	req.Host = req.Header.Get("Host")
+	req.RequestURI = u.RequestURI()
+	req.ContentLength = int64(len(body))
+	if len(body) == 0 {
+		req.Body = http.NoBody
+	}
+
+	return req, nil
+}
+
+// responseWriter captures a net/http response so it can be returned as a Response.
+type responseWriter struct {
+	header      http.Header
+	status      int
+	body        bytes.Buffer
+	wroteHeader bool
+}
+
+var (
+	_ http.ResponseWriter = (*responseWriter)(nil)
+	_ http.Flusher        = (*responseWriter)(nil)
+	_ io.StringWriter     = (*responseWriter)(nil)
+)
+
+// newResponseWriter creates a responseWriter, starting with a copy of the headers already set on the response.
+func newResponseWriter(headers map[string][]string) *responseWriter {
+	header := http.Header(headers).Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &responseWriter{
+		header: header,
+		status: http.StatusOK,
+	}
+}
+
+func (w *responseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+
+	w.status = status
+	w.wroteHeader = true
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	return w.body.Write(b)
+}
+
+func (w *responseWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush is a no-op, as responses are returned once the handler completes.
+func (w *responseWriter) Flush() {}
+
+// writeTo sets the status, headers and body written by the handler on the response.
+func (w *responseWriter) writeTo(resp *Response) {
+	body := w.body.Bytes()
+
+	// match net/http by detecting the content type of responses that don't set one
+	if _, ok := w.header["Content-Type"]; !ok && len(body) > 0 {
+		w.header.Set("Content-Type", http.DetectContentType(body))
+	}
+
+	resp.Status = w.status
+	resp.Headers = w.header
+	resp.Body = body
+}
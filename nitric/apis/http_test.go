@@ -0,0 +1,137 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apis
+
+import (
+	"io"
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/nitrictech/go-sdk/nitric/workers"
+	apispb "github.com/nitrictech/nitric/core/pkg/proto/apis/v1"
+)
+
+var _ = Describe("HttpHandler", func() {
+	It("should convert the request and response", func() {
+		var received *http.Request
+		var receivedBody []byte
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/files/", func(w http.ResponseWriter, r *http.Request) {
+			received = r
+			receivedBody, _ = io.ReadAll(r.Body)
+
+			w.Header().Add("Set-Cookie", "a=1")
+			w.Header().Add("Set-Cookie", "b=2")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte("created"))
+		})
+
+		ctx := NewCtx(&apispb.ServerMessage{
+			Id: "test",
+			Content: &apispb.ServerMessage_HttpRequest{
+				HttpRequest: &apispb.HttpRequest{
+					Method: "POST",
+					Path:   "/files/a/b.txt",
+					Headers: map[string]*apispb.HeaderValue{
+						"x-request-id": {Value: []string{"123"}},
+						"host":         {Value: []string{"example.com"}},
+					},
+					QueryParams: map[string]*apispb.QueryValue{
+						"tag": {Value: []string{"a", "b"}},
+					},
+					Body: []byte("hello"),
+				},
+			},
+		})
+
+		err := HttpHandler(mux)(ctx)
+		Expect(err).ToNot(HaveOccurred())
+
+		By("converting the request")
+		Expect(received).ToNot(BeNil())
+		Expect(received.Method).To(Equal("POST"))
+		Expect(received.URL.Path).To(Equal("/files/a/b.txt"))
+		Expect(received.URL.Query()["tag"]).To(Equal([]string{"a", "b"}))
+		Expect(received.Header.Get("X-Request-Id")).To(Equal("123"))
+		Expect(received.Host).To(Equal("example.com"))
+		Expect(string(receivedBody)).To(Equal("hello"))
+
+		By("converting the response")
+		Expect(ctx.Response.Status).To(Equal(http.StatusCreated))
+		Expect(ctx.Response.Headers["Set-Cookie"]).To(Equal([]string{"a=1", "b=2"}))
+		Expect(ctx.Response.Headers["Content-Type"]).To(Equal([]string{"text/plain; charset=utf-8"}))
+		Expect(string(ctx.Response.Body)).To(Equal("created"))
+	})
+
+	It("should default to a 404 from an empty mux", func() {
+		ctx := newTestCtx("", nil)
+
+		err := HttpHandler(http.NewServeMux())(ctx)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ctx.Response.Status).To(Equal(http.StatusNotFound))
+	})
+
+	It("should keep the response headers set by middleware", func() {
+		handler := Cors(CorsOptions{AllowOrigins: []string{"*"}})(HttpHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Cache-Control", "no-store")
+			_, _ = w.Write([]byte("ok"))
+		})))
+
+		ctx := newCorsCtx(http.MethodGet, map[string]string{
+			"origin": "https://app.example.com",
+		})
+
+		Expect(handler(ctx)).To(Succeed())
+
+		headers := http.Header(ctx.Response.Headers)
+		Expect(ctx.Response.Status).To(Equal(http.StatusOK))
+		Expect(headers.Get("Access-Control-Allow-Origin")).To(Equal("*"))
+		Expect(headers.Get("Cache-Control")).To(Equal("no-store"))
+		Expect(string(ctx.Response.Body)).To(Equal("ok"))
+	})
+})
+
+var _ = Describe("Mount", func() {
+	It("should describe the mounted paths in the OpenAPI document", func() {
+		a := &api{
+			name:    "test-api",
+			routes:  map[string]Route{},
+			manager: workers.New(),
+		}
+
+		a.Mount("/files", http.NewServeMux())
+
+		doc := a.OpenApi()
+
+		Expect(doc.Paths).To(HaveLen(2))
+		Expect(doc.Paths).To(HaveKey("/files"))
+		Expect(doc.Paths).To(HaveKey("/files/{proxy}"))
+
+		for p := range doc.Paths {
+			Expect(p).ToNot(ContainSubstring("*"))
+		}
+
+		op := doc.Paths["/files/{proxy}"]["get"]
+		Expect(op).ToNot(BeNil())
+		Expect(op.Parameters).To(HaveLen(1))
+		Expect(op.Parameters[0].Name).To(Equal("proxy"))
+		Expect(op.Parameters[0].In).To(Equal("path"))
+		Expect(op.Parameters[0].Required).To(BeTrue())
+	})
+})
@@ -0,0 +1,367 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apis
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const openApiVersion = "3.0.3"
+
+type OpenApiDocument struct {
+	OpenApi    string                                  `json:"openapi"`
+	Info       OpenApiInfo                             `json:"info"`
+	Paths      map[string]map[string]*OpenApiOperation `json:"paths"`
+	Components OpenApiComponents                       `json:"components"`
+}
+
+type OpenApiInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type OpenApiComponents struct {
+	Schemas         map[string]*OpenApiSchema         `json:"schemas,omitempty"`
+	SecuritySchemes map[string]*OpenApiSecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type OpenApiSecurityScheme struct {
+	Type             string `json:"type"`
+	OpenIdConnectUrl string `json:"openIdConnectUrl,omitempty"`
+}
+
+type OpenApiOperation struct {
+	OperationId string                      `json:"operationId,omitempty"`
+	Parameters  []*OpenApiParameter         `json:"parameters,omitempty"`
+	RequestBody *OpenApiRequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*OpenApiResponse `json:"responses"`
+	Security    []map[string][]string       `json:"security,omitempty"`
+}
+
+type OpenApiParameter struct {
+	Name     string         `json:"name"`
+	In       string         `json:"in"`
+	Required bool           `json:"required,omitempty"`
+	Schema   *OpenApiSchema `json:"schema,omitempty"`
+}
+
+type OpenApiRequestBody struct {
+	Required bool                         `json:"required,omitempty"`
+	Content  map[string]*OpenApiMediaType `json:"content"`
+}
+
+type OpenApiResponse struct {
+	Description string                       `json:"description"`
+	Content     map[string]*OpenApiMediaType `json:"content,omitempty"`
+}
+
+type OpenApiMediaType struct {
+	Schema *OpenApiSchema `json:"schema,omitempty"`
+}
+
+type OpenApiSchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Nullable             bool                      `json:"nullable,omitempty"`
+	Items                *OpenApiSchema            `json:"items,omitempty"`
+	Properties           map[string]*OpenApiSchema `json:"properties,omitempty"`
+	AdditionalProperties *OpenApiSchema            `json:"additionalProperties,omitempty"`
+	Required             []string                  `json:"required,omitempty"`
+}
+
+// operation records a registered method handler, for use in generating the OpenAPI document.
+type operation struct {
+	path     string
+	methods  []string
+	security []OidcOptions
+	reqType  reflect.Type
+	respType reflect.Type
+}
+
+// OpenApi generates an OpenAPI 3 document describing the routes registered with the API.
+func (a *api) OpenApi() *OpenApiDocument {
+	doc := &OpenApiDocument{
+		OpenApi: openApiVersion,
+		Info: OpenApiInfo{
+			Title:   a.name,
+			Version: "1.0.0",
+		},
+		Paths: map[string]map[string]*OpenApiOperation{},
+		Components: OpenApiComponents{
+			Schemas:         map[string]*OpenApiSchema{},
+			SecuritySchemes: map[string]*OpenApiSecurityScheme{},
+		},
+	}
+
+	for _, op := range a.operations {
+		openApiPath, pathParams := toOpenApiPath(op.path)
+
+		pathItem, ok := doc.Paths[openApiPath]
+		if !ok {
+			pathItem = map[string]*OpenApiOperation{}
+			doc.Paths[openApiPath] = pathItem
+		}
+
+		for _, method := range op.methods {
+			pathItem[strings.ToLower(method)] = doc.newOperation(method, openApiPath, pathParams, op)
+		}
+	}
+
+	return doc
+}
+
+func (d *OpenApiDocument) newOperation(method string, openApiPath string, pathParams []string, op *operation) *OpenApiOperation {
+	o := &OpenApiOperation{
+		OperationId: operationId(method, openApiPath),
+		Responses: map[string]*OpenApiResponse{
+			"200": {Description: http.StatusText(http.StatusOK)},
+		},
+	}
+
+	typedParams := map[string]bool{}
+
+	if op.reqType != nil {
+		reqType := op.reqType
+		for reqType.Kind() == reflect.Pointer {
+			reqType = reqType.Elem()
+		}
+
+		hasBody := reqType.Kind() != reflect.Struct
+
+		if reqType.Kind() == reflect.Struct {
+			for _, field := range reflect.VisibleFields(reqType) {
+				if !field.IsExported() || field.Anonymous {
+					continue
+				}
+
+				param := &OpenApiParameter{}
+				if param.Name = field.Tag.Get("path"); param.Name != "" {
+					param.In = "path"
+					param.Required = true
+				} else if param.Name = field.Tag.Get("query"); param.Name != "" {
+					param.In = "query"
+				} else if param.Name = field.Tag.Get("header"); param.Name != "" {
+					param.In = "header"
+				} else {
+					if jsonFieldName(field) != "" {
+						hasBody = true
+					}
+					continue
+				}
+
+				param.Schema = d.schemaFor(field.Type)
+				typedParams[param.In+":"+param.Name] = true
+				o.Parameters = append(o.Parameters, param)
+			}
+		}
+
+		if hasBody && method != http.MethodGet && method != http.MethodDelete && method != http.MethodOptions {
+			o.RequestBody = &OpenApiRequestBody{
+				Required: true,
+				Content: map[string]*OpenApiMediaType{
+					"application/json": {Schema: d.schemaFor(op.reqType)},
+				},
+			}
+		}
+	}
+
+	for _, name := range pathParams {
+		if typedParams["path:"+name] {
+			continue
+		}
+
+		o.Parameters = append(o.Parameters, &OpenApiParameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   &OpenApiSchema{Type: "string"},
+		})
+	}
+
+	if op.respType != nil {
+		o.Responses["200"].Content = map[string]*OpenApiMediaType{
+			"application/json": {Schema: d.schemaFor(op.respType)},
+		}
+	}
+
+	for _, security := range op.security {
+		d.Components.SecuritySchemes[security.Name] = &OpenApiSecurityScheme{
+			Type:             "openIdConnect",
+			OpenIdConnectUrl: strings.TrimSuffix(security.Issuer, "/") + "/.well-known/openid-configuration",
+		}
+
+		scopes := security.Scopes
+		if scopes == nil {
+			scopes = []string{}
+		}
+
+		o.Security = append(o.Security, map[string][]string{security.Name: scopes})
+	}
+
+	return o
+}
+
+// schemaFor returns the schema for the given type, registering named struct types as component schemas.
+func (d *OpenApiDocument) schemaFor(t reflect.Type) *OpenApiSchema {
+	if t == reflect.TypeOf(time.Time{}) {
+		return &OpenApiSchema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		schema := d.schemaFor(t.Elem())
+		if schema.Ref != "" {
+			return schema
+		}
+		schema.Nullable = true
+		return schema
+	case reflect.Bool:
+		return &OpenApiSchema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &OpenApiSchema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &OpenApiSchema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &OpenApiSchema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &OpenApiSchema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &OpenApiSchema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &OpenApiSchema{Type: "string", Format: "byte"}
+		}
+		return &OpenApiSchema{Type: "array", Items: d.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &OpenApiSchema{Type: "object", AdditionalProperties: d.schemaFor(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return d.structSchema(t)
+		}
+
+		name := t.Name()
+		if _, ok := d.Components.Schemas[name]; !ok {
+			// register a placeholder first to support recursive types
+			d.Components.Schemas[name] = &OpenApiSchema{}
+			*d.Components.Schemas[name] = *d.structSchema(t)
+		}
+
+		return &OpenApiSchema{Ref: "#/components/schemas/" + name}
+	default:
+		return &OpenApiSchema{}
+	}
+}
+
+func (d *OpenApiDocument) structSchema(t reflect.Type) *OpenApiSchema {
+	schema := &OpenApiSchema{
+		Type:       "object",
+		Properties: map[string]*OpenApiSchema{},
+	}
+
+	for _, field := range reflect.VisibleFields(t) {
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+
+		if field.Tag.Get("path") != "" || field.Tag.Get("query") != "" || field.Tag.Get("header") != "" {
+			continue
+		}
+
+		name := jsonFieldName(field)
+		if name == "" {
+			continue
+		}
+
+		schema.Properties[name] = d.schemaFor(field.Type)
+
+		if !strings.Contains(field.Tag.Get("json"), ",omitempty") && field.Type.Kind() != reflect.Pointer {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	sort.Strings(schema.Required)
+
+	return schema
+}
+
+// jsonFieldName returns the name of the field when encoded as JSON, or an empty string if the field is ignored.
+func jsonFieldName(field reflect.StructField) string {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return ""
+	}
+
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name
+	}
+
+	return field.Name
+}
+
+// toOpenApiPath converts a nitric path (e.g. /customers/:id) to an OpenAPI path (e.g. /customers/{id}), returning the path param names.
+// A trailing wildcard segment, such as that of a mounted handler (e.g. /files/*), becomes a {proxy} path param for the rest of the path.
+func toOpenApiPath(nitricPath string) (string, []string) {
+	params := []string{}
+
+	segments := strings.Split(nitricPath, "/")
+	for i, segment := range segments {
+		name := ""
+
+		switch {
+		case strings.HasPrefix(segment, ":"):
+			name = segment[1:]
+		case segment == "*" && i == len(segments)-1:
+			name = "proxy"
+		case segment == "*":
+			name = fmt.Sprintf("wildcard%d", i)
+		default:
+			continue
+		}
+
+		params = append(params, name)
+		segments[i] = "{" + name + "}"
+	}
+
+	return strings.Join(segments, "/"), params
+}
+
+// operationId creates an identifier for an operation from its method and path, e.g. GET /customers/{id} -> getCustomersId
+func operationId(method string, openApiPath string) string {
+	id := strings.ToLower(method)
+
+	upperNext := true
+	for _, r := range openApiPath {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upperNext = true
+			continue
+		}
+
+		if upperNext {
+			r = unicode.ToUpper(r)
+			upperNext = false
+		}
+
+		id += string(r)
+	}
+
+	return id
+}
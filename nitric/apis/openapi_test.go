@@ -0,0 +1,116 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apis
+
+import (
+	"context"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/nitrictech/go-sdk/nitric/workers"
+)
+
+type customer struct {
+	ID    string   `json:"id"`
+	Name  string   `json:"name,omitempty"`
+	Notes *string  `json:"notes"`
+	Tags  []string `json:"tags"`
+}
+
+type updateCustomerRequest struct {
+	ID   string `path:"id"`
+	Name string `json:"name"`
+}
+
+var _ = Describe("OpenApi", func() {
+	var a *api
+
+	BeforeEach(func() {
+		a = &api{
+			name:    "test-api",
+			routes:  map[string]Route{},
+			manager: workers.New(),
+		}
+	})
+
+	When("routes are registered with untyped handlers", func() {
+		It("should describe the paths and path params", func() {
+			a.Get("/customers/:id", func(ctx *Ctx) {})
+
+			doc := a.OpenApi()
+
+			Expect(doc.OpenApi).To(Equal("3.0.3"))
+			Expect(doc.Info.Title).To(Equal("test-api"))
+			Expect(doc.Paths).To(HaveKey("/customers/{id}"))
+
+			op := doc.Paths["/customers/{id}"]["get"]
+			Expect(op).ToNot(BeNil())
+			Expect(op.OperationId).To(Equal("getCustomersId"))
+			Expect(op.Parameters).To(HaveLen(1))
+			Expect(op.Parameters[0].Name).To(Equal("id"))
+			Expect(op.Parameters[0].In).To(Equal("path"))
+		})
+	})
+
+	When("routes are registered with typed handlers", func() {
+		It("should include the request and response schemas", func() {
+			Put(a, "/customers/:id", func(ctx context.Context, req updateCustomerRequest) (customer, error) {
+				return customer{}, nil
+			})
+
+			doc := a.OpenApi()
+			op := doc.Paths["/customers/{id}"]["put"]
+
+			By("describing the request body")
+			Expect(op.RequestBody).ToNot(BeNil())
+			Expect(op.RequestBody.Content["application/json"].Schema.Ref).To(Equal("#/components/schemas/updateCustomerRequest"))
+			Expect(doc.Components.Schemas["updateCustomerRequest"].Properties).To(HaveKey("name"))
+			Expect(doc.Components.Schemas["updateCustomerRequest"].Properties).ToNot(HaveKey("ID"))
+
+			By("describing the response body")
+			Expect(op.Responses["200"].Content["application/json"].Schema.Ref).To(Equal("#/components/schemas/customer"))
+
+			schema := doc.Components.Schemas["customer"]
+			Expect(schema.Properties["tags"].Type).To(Equal("array"))
+			Expect(schema.Properties["notes"].Nullable).To(BeTrue())
+			Expect(schema.Required).To(Equal([]string{"id", "tags"}))
+
+			By("producing valid JSON")
+			_, err := json.Marshal(doc)
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	When("operations are secured", func() {
+		It("should include the security requirements and schemes", func() {
+			a.operations = append(a.operations, &operation{
+				path:    "/admin",
+				methods: []string{"GET"},
+				security: []OidcOptions{{
+					Name:   "user",
+					Issuer: "https://example.com/",
+					Scopes: []string{"admin"},
+				}},
+			})
+
+			doc := a.OpenApi()
+
+			Expect(doc.Paths["/admin"]["get"].Security).To(Equal([]map[string][]string{{"user": {"admin"}}}))
+			Expect(doc.Components.SecuritySchemes["user"].OpenIdConnectUrl).To(Equal("https://example.com/.well-known/openid-configuration"))
+		})
+	})
+})
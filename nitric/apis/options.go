@@ -14,10 +14,18 @@
 
 package apis
 
+import (
+	"reflect"
+	"time"
+
+	"github.com/nitrictech/go-sdk/nitric/workers"
+)
+
 type (
 	ApiOption    func(api *api)
 	RouteOption  func(route Route)
 	MethodOption func(mo *methodOptions)
+	GroupOption  func(g *group)
 )
 
 type JwtSecurityRule struct {
@@ -28,12 +36,21 @@ type JwtSecurityRule struct {
 type methodOptions struct {
 	security         []OidcOptions
 	securityDisabled bool
+	// request and response types of typed handlers, used to generate OpenAPI schemas
+	reqType         reflect.Type
+	respType        reflect.Type
+	excludeFromSpec bool
+	middleware      []Middleware
+	timeout         time.Duration
 }
 
-// WithMiddleware - Apply a middleware function to all handlers in the API
-func WithMiddleware(middleware Middleware) ApiOption {
+// WithMiddleware - Apply middleware functions to all handlers in the API
+//
+// Middleware are called in the order provided, with repeated options appending to the existing middleware.
+// API middleware is called before any group, route or method middleware.
+func WithMiddleware(middleware ...Middleware) ApiOption {
 	return func(api *api) {
-		api.middleware = middleware
+		api.middleware = append(api.middleware, middleware...)
 	}
 }
 
@@ -77,6 +94,80 @@ func WithPath(path string) ApiOption {
 	}
 }
 
+// WithCors - Apply CORS headers to all responses from the API, and answer preflight requests for every route
+//
+// The CORS middleware is called before any other middleware, so preflight requests are answered without
+// calling other middleware or handlers. Routes are registered without security for OPTIONS requests.
+func WithCors(opts CorsOptions) ApiOption {
+	return func(api *api) {
+		api.cors = &opts
+		api.middleware = append([]Middleware{Cors(opts)}, api.middleware...)
+	}
+}
+
+// WithErrorHandler - Customize the problem details returned when a handler in the API returns an error
+func WithErrorHandler(handler ErrorHandler) ApiOption {
+	return func(api *api) {
+		api.errorHandler = handler
+	}
+}
+
+// WithManager - Declare the API with the given manager instead of the default manager
+func WithManager(manager *workers.Manager) ApiOption {
+	return func(api *api) {
+		api.manager = manager
+	}
+}
+
+// WithMaxInFlight - Set the maximum number of requests handled concurrently by each method of the API, defaults to 1 which handles requests in the order received
+func WithMaxInFlight(maxInFlight int) ApiOption {
+	return func(api *api) {
+		api.maxInFlight = maxInFlight
+	}
+}
+
+// WithRouteMiddleware - Apply middleware functions to all handlers on the route
+//
+// Route middleware is called after API and group middleware, and before method middleware.
+func WithRouteMiddleware(middleware ...Middleware) RouteOption {
+	return func(r Route) {
+		if rt, ok := r.(*route); ok {
+			rt.middleware = append(rt.middleware, middleware...)
+		}
+	}
+}
+
+// WithOpenApiRoute - Serve the OpenAPI document for the API as JSON from a GET route at the given path
+func WithOpenApiRoute(path string) ApiOption {
+	return func(api *api) {
+		api.openApiPath = path
+	}
+}
+
+// WithGroupMiddleware - Apply middleware functions to all handlers in the group and its nested groups
+func WithGroupMiddleware(middleware ...Middleware) GroupOption {
+	return func(g *group) {
+		g.middleware = append(g.middleware, middleware...)
+	}
+}
+
+// WithGroupSecurity - Override/set the security settings for all handlers in the group and its nested groups
+func WithGroupSecurity(oidcOptions OidcOptions) GroupOption {
+	return func(g *group) {
+		g.securitySet = true
+		g.securityDisabled = false
+		g.security = append(g.security, oidcOptions)
+	}
+}
+
+// WithNoGroupSecurity - Disable security for all handlers in the group and its nested groups
+func WithNoGroupSecurity() GroupOption {
+	return func(g *group) {
+		g.securitySet = true
+		g.securityDisabled = true
+	}
+}
+
 // WithNoMethodSecurity - Disable security for a method
 func WithNoMethodSecurity() MethodOption {
 	return func(mo *methodOptions) {
@@ -84,6 +175,22 @@ func WithNoMethodSecurity() MethodOption {
 	}
 }
 
+// WithMethodMiddleware - Apply middleware functions to a single method handler
+//
+// Method middleware is the innermost middleware, called after API, group and route middleware.
+func WithMethodMiddleware(middleware ...Middleware) MethodOption {
+	return func(mo *methodOptions) {
+		mo.middleware = append(mo.middleware, middleware...)
+	}
+}
+
+// WithMethodTimeout - Set the maximum duration of each call to the method handler, the Ctx's Context is cancelled once it elapses
+func WithMethodTimeout(timeout time.Duration) MethodOption {
+	return func(mo *methodOptions) {
+		mo.timeout = timeout
+	}
+}
+
 // WithMethodSecurity - Override/set the security settings for a method
 func WithMethodSecurity(oidcOptions OidcOptions) MethodOption {
 	return func(mo *methodOptions) {
@@ -95,3 +202,11 @@ func WithMethodSecurity(oidcOptions OidcOptions) MethodOption {
 		}
 	}
 }
+
+// withTypes - Record the request and response types of a typed handler
+func withTypes[Req any, Resp any]() MethodOption {
+	return func(mo *methodOptions) {
+		mo.reqType = reflect.TypeOf((*Req)(nil)).Elem()
+		mo.respType = reflect.TypeOf((*Resp)(nil)).Elem()
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apis
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+)
+
+// TypedHandlerFunc is a handler with a typed request and response.
+//
+// The request is decoded from the HTTP request, using the fields tagged with
+// `path:"name"`, `query:"name"` and `header:"name"` for path params, query params and headers,
+// with the JSON body decoded into the remaining fields. Tagged fields are never decoded from the body,
+// and the fields of embedded structs are decoded the same way. The response is encoded as JSON.
+type TypedHandlerFunc[Req any, Resp any] func(ctx context.Context, req Req) (Resp, error)
+
+// NewTypedHandler converts a typed handler function into a Handler that can be registered with an Api, Group or Route.
+func NewTypedHandler[Req any, Resp any](handler TypedHandlerFunc[Req, Resp]) Handler {
+	return func(ctx *Ctx) error {
+		var req Req
+
+		if err := decodeRequest(ctx, &req); err != nil {
+			return err
+		}
+
+		resp, err := handler(ctx.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		return ctx.JSON(http.StatusOK, resp)
+	}
+}
+
+// Get adds a typed Get method handler to the path with any specified opts.
+func Get[Req any, Resp any](g Group, path string, handler TypedHandlerFunc[Req, Resp], opts ...MethodOption) {
+	g.Get(path, NewTypedHandler(handler), append(opts, withTypes[Req, Resp]())...)
+}
+
+// Post adds a typed Post method handler to the path with any specified opts.
+func Post[Req any, Resp any](g Group, path string, handler TypedHandlerFunc[Req, Resp], opts ...MethodOption) {
+	g.Post(path, NewTypedHandler(handler), append(opts, withTypes[Req, Resp]())...)
+}
+
+// Put adds a typed Put method handler to the path with any specified opts.
+func Put[Req any, Resp any](g Group, path string, handler TypedHandlerFunc[Req, Resp], opts ...MethodOption) {
+	g.Put(path, NewTypedHandler(handler), append(opts, withTypes[Req, Resp]())...)
+}
+
+// Patch adds a typed Patch method handler to the path with any specified opts.
+func Patch[Req any, Resp any](g Group, path string, handler TypedHandlerFunc[Req, Resp], opts ...MethodOption) {
+	g.Patch(path, NewTypedHandler(handler), append(opts, withTypes[Req, Resp]())...)
+}
+
+// Delete adds a typed Delete method handler to the path with any specified opts.
+func Delete[Req any, Resp any](g Group, path string, handler TypedHandlerFunc[Req, Resp], opts ...MethodOption) {
+	g.Delete(path, NewTypedHandler(handler), append(opts, withTypes[Req, Resp]())...)
+}
+
+// decodeRequest populates req from the body, path params, query params and headers of the request.
+func decodeRequest(ctx *Ctx, req interface{}) error {
+	if len(ctx.Request.Data()) > 0 {
+		if err := ctx.Bind(req); err != nil {
+			return err
+		}
+	}
+
+	v := reflect.ValueOf(req).Elem()
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	for _, field := range reflect.VisibleFields(v.Type()) {
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+
+		var values []string
+		var source, name string
+
+		if name = field.Tag.Get("path"); name != "" {
+			source = "path param"
+			if value, ok := ctx.Request.PathParams()[name]; ok {
+				values = []string{value}
+			}
+		} else if name = field.Tag.Get("query"); name != "" {
+			source = "query param"
+			values = ctx.Request.Query()[name]
+		} else if name = field.Tag.Get("header"); name != "" {
+			source = "header"
+			if value := headerValue(ctx.Request.Headers(), name); value != "" {
+				values = []string{value}
+			}
+		} else {
+			continue
+		}
+
+		// fields tagged as params or headers aren't part of the body, so discard any value decoded from it
+		if fieldValue, ok := fieldByIndex(v, field.Index, false); ok {
+			fieldValue.SetZero()
+		}
+
+		if len(values) == 0 {
+			continue
+		}
+
+		fieldValue, ok := fieldByIndex(v, field.Index, true)
+		if !ok {
+			continue
+		}
+
+		if err := setFieldValue(fieldValue, values); err != nil {
+			return NewHttpErrorWithCause(http.StatusBadRequest, fmt.Sprintf("invalid %s %s", source, name), err)
+		}
+	}
+
+	return nil
+}
+
+// fieldByIndex returns the settable field of v with the given index, which may be a field of an embedded struct.
+// Nil pointers to embedded structs are allocated if alloc is true, otherwise the field isn't returned.
+func fieldByIndex(v reflect.Value, index []int, alloc bool) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !alloc || !v.CanSet() {
+					return reflect.Value{}, false
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+
+		v = v.Field(x)
+	}
+
+	return v, v.CanSet()
+}
+
+// setFieldValue parses the string values into the given field, based on its kind.
+func setFieldValue(field reflect.Value, values []string) error {
+	switch field.Kind() {
+	case reflect.Pointer:
+		value := reflect.New(field.Type().Elem())
+		if err := setFieldValue(value.Elem(), values); err != nil {
+			return err
+		}
+		field.Set(value)
+	case reflect.Slice:
+		slice := reflect.MakeSlice(field.Type(), len(values), len(values))
+		for i, value := range values {
+			if err := setFieldValue(slice.Index(i), []string{value}); err != nil {
+				return err
+			}
+		}
+		field.Set(slice)
+	case reflect.String:
+		field.SetString(values[0])
+	case reflect.Bool:
+		b, err := strconv.ParseBool(values[0])
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(values[0], 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(values[0], 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(values[0], field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+
+	return nil
+}
@@ -0,0 +1,179 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apis
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	apispb "github.com/nitrictech/nitric/core/pkg/proto/apis/v1"
+)
+
+type getCustomerRequest struct {
+	ID     string   `path:"id"`
+	Limit  int      `query:"limit"`
+	Tags   []string `query:"tag"`
+	Tenant string   `header:"X-Tenant"`
+	Name   string   `json:"name"`
+}
+
+type tenantParams struct {
+	Tenant string `header:"X-Tenant"`
+	Limit  int    `query:"limit"`
+}
+
+type listOrdersRequest struct {
+	tenantParams
+	*PageParams
+	CustomerID string `path:"id"`
+	Status     string `json:"status"`
+}
+
+// PageParams is exported, so a nil pointer to it can be allocated when decoding
+type PageParams struct {
+	Tags []string `query:"tag"`
+}
+
+type getCustomerResponse struct {
+	ID string `json:"id"`
+}
+
+var _ = Describe("NewTypedHandler", func() {
+	var (
+		received getCustomerRequest
+		handler  Handler
+	)
+
+	BeforeEach(func() {
+		handler = NewTypedHandler(func(ctx context.Context, req getCustomerRequest) (getCustomerResponse, error) {
+			received = req
+
+			return getCustomerResponse{ID: req.ID}, nil
+		})
+	})
+
+	newCtx := func(query map[string][]string, body []byte) *Ctx {
+		queryParams := map[string]*apispb.QueryValue{}
+		for k, v := range query {
+			queryParams[k] = &apispb.QueryValue{Value: v}
+		}
+
+		return NewCtx(&apispb.ServerMessage{
+			Id: "test",
+			Content: &apispb.ServerMessage_HttpRequest{
+				HttpRequest: &apispb.HttpRequest{
+					Method:      "POST",
+					Path:        "/customers/123",
+					PathParams:  map[string]string{"id": "123"},
+					QueryParams: queryParams,
+					Headers: map[string]*apispb.HeaderValue{
+						"x-tenant": {Value: []string{"acme"}},
+					},
+					Body: body,
+				},
+			},
+		})
+	}
+
+	When("the request is valid", func() {
+		It("should decode the request and encode the response", func() {
+			ctx := newCtx(map[string][]string{"limit": {"10"}, "tag": {"a", "b"}}, []byte(`{"name":"test"}`))
+
+			err := handler(ctx)
+
+			By("decoding the request")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(received).To(Equal(getCustomerRequest{
+				ID:     "123",
+				Limit:  10,
+				Tags:   []string{"a", "b"},
+				Tenant: "acme",
+				Name:   "test",
+			}))
+
+			By("encoding the response")
+			Expect(ctx.Response.Status).To(Equal(200))
+			Expect(string(ctx.Response.Body)).To(Equal(`{"id":"123"}`))
+		})
+	})
+
+	When("the body contains fields tagged as params or headers", func() {
+		It("should not decode them from the body", func() {
+			ctx := newCtx(nil, []byte(`{"ID":"456","Limit":5,"Tenant":"other","name":"test"}`))
+
+			Expect(handler(ctx)).To(Succeed())
+			Expect(received).To(Equal(getCustomerRequest{
+				ID:     "123",
+				Tenant: "acme",
+				Name:   "test",
+			}))
+		})
+	})
+
+	When("the request embeds structs with tagged fields", func() {
+		It("should decode the fields of the embedded structs", func() {
+			var received listOrdersRequest
+			handler := NewTypedHandler(func(ctx context.Context, req listOrdersRequest) (getCustomerResponse, error) {
+				received = req
+
+				return getCustomerResponse{}, nil
+			})
+
+			ctx := newCtx(map[string][]string{"limit": {"10"}, "tag": {"a", "b"}}, []byte(`{"status":"open"}`))
+
+			Expect(handler(ctx)).To(Succeed())
+			Expect(received.CustomerID).To(Equal("123"))
+			Expect(received.Status).To(Equal("open"))
+			Expect(received.Tenant).To(Equal("acme"))
+			Expect(received.Limit).To(Equal(10))
+			Expect(received.PageParams).ToNot(BeNil())
+			Expect(received.Tags).To(Equal([]string{"a", "b"}))
+		})
+	})
+
+	When("the Ctx has a context", func() {
+		It("should pass the context to the typed handler", func() {
+			type ctxKey struct{}
+			var received context.Context
+
+			handler := NewTypedHandler(func(ctx context.Context, req getCustomerRequest) (getCustomerResponse, error) {
+				received = ctx
+
+				return getCustomerResponse{}, nil
+			})
+
+			ctx := newCtx(nil, nil)
+			ctx.SetContext(context.WithValue(context.Background(), ctxKey{}, "value"))
+
+			Expect(handler(ctx)).To(Succeed())
+			Expect(received.Value(ctxKey{})).To(Equal("value"))
+		})
+	})
+
+	When("a param cannot be parsed", func() {
+		It("should return a 400 error", func() {
+			ctx := newCtx(map[string][]string{"limit": {"ten"}}, nil)
+
+			err := handler(ctx)
+
+			var httpErr *HttpError
+			Expect(errors.As(err, &httpErr)).To(BeTrue())
+			Expect(httpErr.Status).To(Equal(400))
+		})
+	})
+})
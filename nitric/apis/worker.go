@@ -17,9 +17,16 @@ package apis
 import (
 	"context"
 	errorsstd "errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
 
-	grpcx "github.com/nitrictech/go-sdk/internal/grpc"
 	"github.com/nitrictech/go-sdk/internal/handlers"
+	"github.com/nitrictech/go-sdk/internal/tracing"
 	"github.com/nitrictech/go-sdk/nitric/errors"
 	"github.com/nitrictech/go-sdk/nitric/errors/codes"
 	"github.com/nitrictech/go-sdk/nitric/workers"
@@ -30,17 +37,31 @@ type apiWorker struct {
 	client              v1.ApiClient
 	Handler             handlers.Handler[Ctx]
 	registrationRequest *v1.RegistrationRequest
+	errorHandler        ErrorHandler
+	timeout             time.Duration
+	maxInFlight         int
 }
 
 type apiWorkerOpts struct {
+	Manager             *workers.Manager
 	RegistrationRequest *v1.RegistrationRequest
 	Handler             handlers.Handler[Ctx]
+	ErrorHandler        ErrorHandler
+	Timeout             time.Duration
+	MaxInFlight         int
 }
 
 var _ workers.StreamWorker = (*apiWorker)(nil)
 
+// Registration implements workers.RegisteredWorker.
+func (a *apiWorker) Registration() proto.Message {
+	return a.registrationRequest
+}
+
 // Start runs the API worker, creating a stream to the Nitric server
 func (a *apiWorker) Start(ctx context.Context) error {
+	logger := workers.Logger(ctx).With("api", a.registrationRequest.Api, "path", a.registrationRequest.Path)
+
 	initReq := &v1.ClientMessage{
 		Content: &v1.ClientMessage_RegistrationRequest{
 			RegistrationRequest: a.registrationRequest,
@@ -60,10 +81,40 @@ func (a *apiWorker) Start(ctx context.Context) error {
 		if msg.GetHttpRequest() != nil {
 			handlerCtx := NewCtx(msg)
 
-			err := a.Handler(handlerCtx)
+			reqCtx, cancel := workers.HandlerContext(ctx, a.timeout)
+			defer cancel()
+			reqCtx = tracing.ExtractHeaders(reqCtx, handlerCtx.Request.Headers())
+			reqCtx, span := tracing.Start(reqCtx, handlerCtx.Request.Method()+" "+a.registrationRequest.Path, trace.SpanKindServer,
+				attribute.String("http.request.method", handlerCtx.Request.Method()),
+				attribute.String("http.route", a.registrationRequest.Path),
+				attribute.String("url.path", handlerCtx.Request.Path()),
+				attribute.String("nitric.api.name", a.registrationRequest.Api),
+			)
+			handlerCtx.SetContext(reqCtx)
+			handlerCtx.SetLogger(logger.With("message_id", msg.Id))
+			handlerCtx.errorHandler = a.errorHandler
+
+			done := workers.Observe(ctx)
+			err := workers.Recover(ctx, func() error {
+				return a.Handler(handlerCtx)
+			})
 			if err != nil {
 				handlerCtx.WithError(err)
+				// client errors are expected, so they're only logged when debugging
+				level := slog.LevelDebug
+				if handlerCtx.Response.Status >= http.StatusInternalServerError {
+					level = slog.LevelError
+				}
+				handlerCtx.Logger().Log(reqCtx, level, "handler failed", "status", handlerCtx.Response.Status, "error", err)
+			}
+
+			span.SetAttributes(attribute.Int("http.response.status_code", handlerCtx.Response.Status))
+			if handlerCtx.Response.Status < http.StatusInternalServerError {
+				// client errors aren't recorded as errors of server spans
+				err = nil
 			}
+			tracing.End(span, err)
+			done(err)
 
 			return handlerCtx.ToClientMessage(), nil
 		}
@@ -80,11 +131,12 @@ func (a *apiWorker) Start(ctx context.Context) error {
 		createStream,
 		initReq,
 		handlerSrvMsg,
+		workers.WithMaxInFlight(a.maxInFlight),
 	)
 }
 
 func newApiWorker(opts *apiWorkerOpts) *apiWorker {
-	conn, err := grpcx.GetConnection()
+	conn, err := opts.Manager.Connection()
 	if err != nil {
 		panic(errors.NewWithCause(
 			codes.Unavailable,
@@ -99,5 +151,8 @@ func newApiWorker(opts *apiWorkerOpts) *apiWorker {
 		client:              client,
 		registrationRequest: opts.RegistrationRequest,
 		Handler:             opts.Handler,
+		timeout:             opts.Timeout,
+		maxInFlight:         opts.MaxInFlight,
+		errorHandler:        opts.ErrorHandler,
 	}
 }
@@ -26,7 +26,10 @@ import (
 // JobPermission defines the available permissions on a job
 type JobPermission string
 
-type Handler = handlers.Handler[Ctx]
+type (
+	Handler    = handlers.Handler[Ctx]
+	Middleware = handlers.Middleware[Ctx]
+)
 
 const (
 	// JobSubmit is required to call Submit on a job.
@@ -54,13 +57,26 @@ type jobReference struct {
 	registerChan <-chan workers.RegisterResult
 }
 
+type JobOption func(*jobReference)
+
+// WithManager - Declare the job with the given manager instead of the default manager
+func WithManager(manager *workers.Manager) JobOption {
+	return func(j *jobReference) {
+		j.manager = manager
+	}
+}
+
 // NewJob creates a new job resource with the give name.
-func NewJob(name string) JobReference {
+func NewJob(name string, opts ...JobOption) JobReference {
 	job := &jobReference{
 		name:    name,
 		manager: workers.GetDefaultManager(),
 	}
 
+	for _, opt := range opts {
+		opt(job)
+	}
+
 	job.registerChan = job.manager.RegisterResource(&v1.ResourceDeclareRequest{
 		Id: &v1.ResourceIdentifier{
 			Type: v1.ResourceType_Job,
@@ -97,12 +113,12 @@ func (j *jobReference) Allow(permission JobPermission, permissions ...JobPermiss
 		panic(err)
 	}
 
-	client, err := NewBatchClient(j.name)
+	conn, err := j.manager.Connection()
 	if err != nil {
 		panic(err)
 	}
 
-	return client
+	return newBatchClient(j.name, conn)
 }
 
 func (j *jobReference) Handler(handler interface{}, opts ...HandlerOption) {
@@ -135,8 +151,11 @@ func (j *jobReference) Handler(handler interface{}, opts ...HandlerOption) {
 	}
 
 	jobOpts := &jobWorkerOpts{
+		Manager:             j.manager,
 		RegistrationRequest: registrationRequest,
-		Handler:             typedHandler,
+		Handler:             handlers.Chain(typedHandler, options.Middleware...),
+		Timeout:             options.Timeout,
+		MaxInFlight:         options.MaxInFlight,
 	}
 
 	worker := newJobWorker(jobOpts)
@@ -17,11 +17,15 @@ package batch
 import (
 	"context"
 
+	"go.opentelemetry.io/otel/attribute"
 	"google.golang.org/grpc"
 
-	"github.com/nitrictech/go-sdk/constants"
+	grpcx "github.com/nitrictech/go-sdk/internal/grpc"
+	"github.com/nitrictech/go-sdk/internal/tracing"
+	"github.com/nitrictech/go-sdk/nitric/clients"
 	"github.com/nitrictech/go-sdk/nitric/errors"
 	"github.com/nitrictech/go-sdk/nitric/errors/codes"
+	"github.com/nitrictech/go-sdk/nitric/metrics"
 	v1 "github.com/nitrictech/nitric/core/pkg/proto/batch/v1"
 	"github.com/nitrictech/protoutils"
 )
@@ -44,8 +48,18 @@ func (s *BatchClient) Name() string {
 	return s.name
 }
 
-func (s *BatchClient) Submit(ctx context.Context, data map[string]interface{}) error {
-	dataStruct, err := protoutils.NewStruct(data)
+func (s *BatchClient) Submit(ctx context.Context, data map[string]interface{}) (err error) {
+	ctx, span := tracing.StartClient(
+		ctx, "Batch.Submit",
+		attribute.String("nitric.job.name", s.name),
+	)
+	done := metrics.Start(metrics.Client, s.name, "Batch.Submit")
+	defer func() {
+		tracing.End(span, err)
+		done(err)
+	}()
+
+	dataStruct, err := protoutils.NewStruct(tracing.InjectMessage(ctx, data))
 	if err != nil {
 		return errors.NewWithCause(codes.InvalidArgument, "Batch.Submit", err)
 	}
@@ -69,8 +83,17 @@ func (s *BatchClient) Submit(ctx context.Context, data map[string]interface{}) e
 	return nil
 }
 
-func NewBatchClient(name string) (*BatchClient, error) {
-	conn, err := grpc.NewClient(constants.NitricAddress(), constants.DefaultOptions()...)
+// ClientOption configures a BatchClient, the options are defined in the clients package.
+type ClientOption = clients.Option
+
+// WithConnection and WithRetryPolicy set the connection the BatchClient uses and the policy its calls are retried with, Submit is only retried if the policy sets NonIdempotent.
+var (
+	WithConnection  = clients.WithConnection
+	WithRetryPolicy = clients.WithRetryPolicy
+)
+
+func NewBatchClient(name string, opts ...ClientOption) (*BatchClient, error) {
+	conn, err := grpcx.ClientConnection(opts...)
 	if err != nil {
 		return nil, errors.NewWithCause(
 			codes.Unavailable,
@@ -79,10 +102,14 @@ func NewBatchClient(name string) (*BatchClient, error) {
 		)
 	}
 
+	return newBatchClient(name, conn), nil
+}
+
+func newBatchClient(name string, conn grpc.ClientConnInterface) *BatchClient {
 	batchClient := v1.NewBatchClient(conn)
 
 	return &BatchClient{
 		name:        name,
 		batchClient: batchClient,
-	}, nil
+	}
 }
@@ -14,9 +14,14 @@
 
 package batch
 
-import batchpb "github.com/nitrictech/nitric/core/pkg/proto/batch/v1"
+import (
+	"github.com/nitrictech/go-sdk/internal/handlers"
+	batchpb "github.com/nitrictech/nitric/core/pkg/proto/batch/v1"
+)
 
 type Ctx struct {
+	handlers.RequestContext
+
 	id       string
 	Request  Request
 	Response *Response
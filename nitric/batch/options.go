@@ -14,10 +14,17 @@
 
 package batch
 
+import (
+	"time"
+
+	"github.com/nitrictech/go-sdk/internal/handlers"
+)
+
 type HandlerOption func(opts *handlerOptions)
 
-// HandlerOptions defines the resource requirements for a job
+// HandlerOptions defines the resource requirements for a job, along with the handler options shared with the other triggers
 type handlerOptions struct {
+	handlers.Options[Ctx]
 	// Cpus is the number of CPUs/vCPUs to allocate to the job
 	cpus *float32
 	// Memory is the amount of memory in MiB to allocate to the job
@@ -46,3 +53,32 @@ func WithGpus(gpus int64) HandlerOption {
 		opts.gpus = &gpus
 	}
 }
+
+// WithMiddleware - Apply middleware functions to the job handler, called in the order provided
+func WithMiddleware(middleware ...Middleware) HandlerOption {
+	return withHandlerOption(handlers.WithMiddleware[Ctx](middleware...))
+}
+
+// WithTimeout - Set the maximum duration of each call to the job handler, the Ctx's Context is cancelled once it elapses
+func WithTimeout(timeout time.Duration) HandlerOption {
+	return withHandlerOption(handlers.WithTimeout[Ctx](timeout))
+}
+
+// WithMaxInFlight - Set the maximum number of jobs handled concurrently, defaults to 1 which handles them in the order received
+func WithMaxInFlight(maxInFlight int) HandlerOption {
+	return withHandlerOption(handlers.WithMaxInFlight[Ctx](maxInFlight))
+}
+
+// withHandlerOption applies an option shared with the handlers of the other triggers.
+func withHandlerOption(opt handlers.Option[Ctx]) HandlerOption {
+	return func(opts *handlerOptions) {
+		opt(&opts.Options)
+	}
+}
+
+// Chain wraps a job handler in middleware, and Compose combines middleware into one, e.g. to share a stack between jobs.
+// The first middleware is the outermost, so it is called first and returns last.
+var (
+	Chain   = handlers.Chain[Ctx]
+	Compose = handlers.Compose[Ctx]
+)
@@ -16,12 +16,15 @@ package batch
 
 import (
 	"context"
+	"time"
 
-	"google.golang.org/grpc"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
 
 	errorsstd "errors"
 
-	"github.com/nitrictech/go-sdk/constants"
+	"github.com/nitrictech/go-sdk/internal/tracing"
 	"github.com/nitrictech/go-sdk/nitric/errors"
 	"github.com/nitrictech/go-sdk/nitric/errors/codes"
 	"github.com/nitrictech/go-sdk/nitric/workers"
@@ -32,14 +35,26 @@ type jobWorker struct {
 	client              v1.JobClient
 	registrationRequest *v1.RegistrationRequest
 	handler             Handler
+	timeout             time.Duration
+	maxInFlight         int
 }
 type jobWorkerOpts struct {
+	Manager             *workers.Manager
 	RegistrationRequest *v1.RegistrationRequest
 	Handler             Handler
+	Timeout             time.Duration
+	MaxInFlight         int
+}
+
+// Registration implements workers.RegisteredWorker.
+func (s *jobWorker) Registration() proto.Message {
+	return s.registrationRequest
 }
 
 // Start runs the Job worker, creating a stream to the Nitric server
 func (s *jobWorker) Start(ctx context.Context) error {
+	logger := workers.Logger(ctx).With("job", s.registrationRequest.JobName)
+
 	initReq := &v1.ClientMessage{
 		Content: &v1.ClientMessage_RegistrationRequest{
 			RegistrationRequest: s.registrationRequest,
@@ -54,10 +69,26 @@ func (s *jobWorker) Start(ctx context.Context) error {
 		if msg.GetJobRequest() != nil {
 			handlerCtx := NewCtx(msg)
 
-			err := s.handler(handlerCtx)
+			reqCtx, cancel := workers.HandlerContext(ctx, s.timeout)
+			defer cancel()
+			reqCtx = tracing.ExtractMessage(reqCtx, handlerCtx.Request.Data())
+			reqCtx, span := tracing.Start(reqCtx, s.registrationRequest.JobName+" process", trace.SpanKindConsumer,
+				attribute.String("nitric.job.name", s.registrationRequest.JobName),
+				attribute.String("nitric.message.id", msg.Id),
+			)
+			handlerCtx.SetContext(reqCtx)
+			handlerCtx.SetLogger(logger.With("message_id", msg.Id))
+
+			done := workers.Observe(ctx)
+			err := workers.Recover(ctx, func() error {
+				return s.handler(handlerCtx)
+			})
 			if err != nil {
+				handlerCtx.Logger().Error("handler failed", "error", err)
 				handlerCtx.WithError(err)
 			}
+			tracing.End(span, err)
+			done(err)
 
 			return handlerCtx.ToClientMessage(), nil
 		}
@@ -69,11 +100,11 @@ func (s *jobWorker) Start(ctx context.Context) error {
 		)
 	}
 
-	return workers.HandleStream(ctx, createStream, initReq, handleSrvMsg)
+	return workers.HandleStream(ctx, createStream, initReq, handleSrvMsg, workers.WithMaxInFlight(s.maxInFlight))
 }
 
 func newJobWorker(opts *jobWorkerOpts) *jobWorker {
-	conn, err := grpc.NewClient(constants.NitricAddress(), constants.DefaultOptions()...)
+	conn, err := opts.Manager.Connection()
 	if err != nil {
 		panic(errors.NewWithCause(
 			codes.Unavailable,
@@ -88,5 +119,7 @@ func newJobWorker(opts *jobWorkerOpts) *jobWorker {
 		client:              client,
 		registrationRequest: opts.RegistrationRequest,
 		handler:             opts.Handler,
+		timeout:             opts.Timeout,
+		maxInFlight:         opts.MaxInFlight,
 	}
 }
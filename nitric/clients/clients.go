@@ -0,0 +1,38 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package clients provides the options accepted by every client in the SDK, such as KvStoreClient and TopicClient.
+//
+// Each client package re-exports these options, e.g. keyvalue.WithConnection, so they can be used without importing this package.
+package clients
+
+import (
+	"google.golang.org/grpc"
+
+	grpcx "github.com/nitrictech/go-sdk/internal/grpc"
+	"github.com/nitrictech/go-sdk/nitric/retry"
+)
+
+// Option configures a client.
+type Option = grpcx.ClientOption
+
+// WithConnection - Use the connection for the client instead of the shared connection
+func WithConnection(conn grpc.ClientConnInterface) Option {
+	return grpcx.WithConnection(conn)
+}
+
+// WithRetryPolicy - Retry the client's calls with the policy instead of the default policy, see the retry package for the calls that are retried
+func WithRetryPolicy(policy retry.Policy) Option {
+	return grpcx.WithRetryPolicy(policy)
+}
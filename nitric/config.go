@@ -0,0 +1,173 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nitric
+
+import (
+	"crypto/tls"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+
+	grpcx "github.com/nitrictech/go-sdk/internal/grpc"
+	"github.com/nitrictech/go-sdk/nitric/errors"
+	"github.com/nitrictech/go-sdk/nitric/errors/codes"
+)
+
+// Config configures the connection to the Nitric server, see ConfigFromEnv for the environment variables that set it.
+type Config = grpcx.Config
+
+// ConfigFromEnv returns the connection config set by the environment:
+//
+//	SERVICE_ADDRESS                         host:port or unix:///path/to/socket of the Nitric server
+//	SERVICE_DIAL_TIMEOUT                    milliseconds to wait for the Nitric server to become reachable at startup
+//	NITRIC_TLS                              "true" to connect with TLS
+//	NITRIC_TLS_CA_FILE                      PEM CA certificates to verify the server with
+//	NITRIC_TLS_CERT_FILE, NITRIC_TLS_KEY_FILE  PEM client certificate and key for mutual TLS
+//	NITRIC_TLS_SERVER_NAME                  server name to verify the server's certificate with
+//	NITRIC_KEEPALIVE_TIME                   interval of keepalive pings, e.g. "30s"
+//	NITRIC_KEEPALIVE_TIMEOUT                time to wait for a keepalive ping to be acknowledged
+//	NITRIC_KEEPALIVE_PERMIT_WITHOUT_STREAM  "true" to send keepalive pings without active streams
+//	NITRIC_MAX_RECV_MSG_SIZE                maximum size of received messages in bytes
+//	NITRIC_MAX_SEND_MSG_SIZE                maximum size of sent messages in bytes
+func ConfigFromEnv() (Config, error) {
+	return grpcx.ConfigFromEnv()
+}
+
+// Option configures the connection to the Nitric server.
+type Option func(*Config)
+
+// WithAddress - Connect to the Nitric server at the address, either host:port or a unix socket such as unix:///var/run/nitric.sock
+func WithAddress(address string) Option {
+	return func(c *Config) {
+		c.Address = address
+	}
+}
+
+// WithTLS - Connect with TLS using the config, or the system's root CAs if the config is nil
+func WithTLS(config *tls.Config) Option {
+	return func(c *Config) {
+		c.TLS = true
+		c.TLSConfig = config
+	}
+}
+
+// WithTLSFiles - Connect with TLS, verifying the server with the CA file, and using mutual TLS if a certificate and key file are given
+//
+// Any of the files may be empty, the CA file defaults to the system's root CAs.
+func WithTLSFiles(caFile string, certFile string, keyFile string) Option {
+	return func(c *Config) {
+		c.TLS = true
+		c.TLSCAFile = caFile
+		c.TLSCertFile = certFile
+		c.TLSKeyFile = keyFile
+	}
+}
+
+// WithKeepalive - Set the keepalive parameters of the connection
+func WithKeepalive(params keepalive.ClientParameters) Option {
+	return func(c *Config) {
+		c.Keepalive = &params
+	}
+}
+
+// WithConnectParams - Set the backoff used to retry connecting to the Nitric server, and the minimum time to wait for each attempt
+func WithConnectParams(params grpc.ConnectParams) Option {
+	return func(c *Config) {
+		c.ConnectParams = &params
+	}
+}
+
+// WithMaxMessageSize - Set the maximum size in bytes of messages received from and sent to the Nitric server
+func WithMaxMessageSize(recv int, send int) Option {
+	return func(c *Config) {
+		c.MaxRecvMsgSize = recv
+		c.MaxSendMsgSize = send
+	}
+}
+
+// WithDialOptions - Add gRPC dial options, applied after the options from the rest of the config
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(c *Config) {
+		c.DialOptions = append(c.DialOptions, opts...)
+	}
+}
+
+func newConfig(opts []Option) (Config, error) {
+	config, err := grpcx.ConfigFromEnv()
+	if err != nil {
+		return Config{}, err
+	}
+
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	return config, nil
+}
+
+// Configure sets the config of the connection used by all resources, clients and workers, applying the options to the config from the environment.
+//
+// Configure must be called before any resources are declared, as they create the connection.
+func Configure(opts ...Option) error {
+	config, err := newConfig(opts)
+	if err != nil {
+		return errors.NewWithCause(codes.InvalidArgument, "Configure: invalid config", err)
+	}
+
+	if err := grpcx.SetConfig(config); err != nil {
+		return errors.NewWithCause(codes.FailedPrecondition, "Configure: resources have already been declared", err)
+	}
+
+	return nil
+}
+
+// NewConnection creates a connection to the Nitric server, applying the options to the config from the environment.
+//
+// Use it with workers.Manager.SetConnection to connect a manager independently of the shared connection.
+func NewConnection(opts ...Option) (*grpc.ClientConn, error) {
+	config, err := newConfig(opts)
+	if err != nil {
+		return nil, errors.NewWithCause(codes.InvalidArgument, "NewConnection: invalid config", err)
+	}
+
+	conn, err := config.Dial()
+	if err != nil {
+		return nil, errors.NewWithCause(codes.Unavailable, "NewConnection: unable to create connection", err)
+	}
+
+	return conn, nil
+}
+
+// SetConnection replaces the connection used by all resources, clients and workers, e.g. with an in-memory connection in tests.
+//
+// SetConnection must be called before any resources are declared, as they keep using the connection they were declared with.
+func SetConnection(conn grpc.ClientConnInterface) {
+	grpcx.SetConnection(conn)
+}
+
+// AddUnaryInterceptors adds interceptors that run around the unary calls made by every resource, client and worker,
+// e.g. to add auth metadata or log requests.
+//
+// Interceptors added to a workers.Manager run after these.
+func AddUnaryInterceptors(interceptors ...grpc.UnaryClientInterceptor) {
+	grpcx.AddUnaryInterceptors(interceptors...)
+}
+
+// AddStreamInterceptors adds interceptors that run around the streams opened by every worker and client.
+//
+// Interceptors added to a workers.Manager run after these.
+func AddStreamInterceptors(interceptors ...grpc.StreamClientInterceptor) {
+	grpcx.AddStreamInterceptors(interceptors...)
+}
@@ -34,6 +34,11 @@ func (a *ApiError) Unwrap() error {
 	return a.cause
 }
 
+// Message - returns the message of the error, without the code or cause
+func (a *ApiError) Message() string {
+	return a.msg
+}
+
 func (a *ApiError) Error() string {
 	if a.cause != nil {
 		// If the wrapped error is an ApiError than these should unwrap
@@ -17,9 +17,15 @@ package keyvalue
 import (
 	"context"
 
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc"
+
 	grpcx "github.com/nitrictech/go-sdk/internal/grpc"
+	"github.com/nitrictech/go-sdk/internal/tracing"
+	"github.com/nitrictech/go-sdk/nitric/clients"
 	"github.com/nitrictech/go-sdk/nitric/errors"
 	"github.com/nitrictech/go-sdk/nitric/errors/codes"
+	"github.com/nitrictech/go-sdk/nitric/metrics"
 	"github.com/nitrictech/protoutils"
 
 	v1 "github.com/nitrictech/nitric/core/pkg/proto/kvstore/v1"
@@ -72,7 +78,18 @@ func (s *KvStoreClient) Name() string {
 	return s.name
 }
 
-func (s *KvStoreClient) Get(ctx context.Context, key string) (map[string]interface{}, error) {
+func (s *KvStoreClient) Get(ctx context.Context, key string) (_ map[string]interface{}, err error) {
+	ctx, span := tracing.StartClient(
+		ctx, "Store.Get",
+		attribute.String("nitric.kv.store", s.name),
+		attribute.String("nitric.kv.key", key),
+	)
+	done := metrics.Start(metrics.Client, s.name, "Store.Get")
+	defer func() {
+		tracing.End(span, err)
+		done(err)
+	}()
+
 	ref := &v1.ValueRef{
 		Store: s.name,
 		Key:   key,
@@ -94,7 +111,18 @@ func (s *KvStoreClient) Get(ctx context.Context, key string) (map[string]interfa
 	return content, nil
 }
 
-func (s *KvStoreClient) Set(ctx context.Context, key string, value map[string]interface{}) error {
+func (s *KvStoreClient) Set(ctx context.Context, key string, value map[string]interface{}) (err error) {
+	ctx, span := tracing.StartClient(
+		ctx, "Store.Set",
+		attribute.String("nitric.kv.store", s.name),
+		attribute.String("nitric.kv.key", key),
+	)
+	done := metrics.Start(metrics.Client, s.name, "Store.Set")
+	defer func() {
+		tracing.End(span, err)
+		done(err)
+	}()
+
 	ref := &v1.ValueRef{
 		Store: s.name,
 		Key:   key,
@@ -117,13 +145,24 @@ func (s *KvStoreClient) Set(ctx context.Context, key string, value map[string]in
 	return nil
 }
 
-func (s *KvStoreClient) Delete(ctx context.Context, key string) error {
+func (s *KvStoreClient) Delete(ctx context.Context, key string) (err error) {
+	ctx, span := tracing.StartClient(
+		ctx, "Store.Delete",
+		attribute.String("nitric.kv.store", s.name),
+		attribute.String("nitric.kv.key", key),
+	)
+	done := metrics.Start(metrics.Client, s.name, "Store.Delete")
+	defer func() {
+		tracing.End(span, err)
+		done(err)
+	}()
+
 	ref := &v1.ValueRef{
 		Store: s.name,
 		Key:   key,
 	}
 
-	_, err := s.kvClient.DeleteKey(ctx, &v1.KvStoreDeleteKeyRequest{
+	_, err = s.kvClient.DeleteKey(ctx, &v1.KvStoreDeleteKeyRequest{
 		Ref: ref,
 	})
 	if err != nil {
@@ -133,7 +172,17 @@ func (s *KvStoreClient) Delete(ctx context.Context, key string) error {
 	return nil
 }
 
-func (s *KvStoreClient) Keys(ctx context.Context, opts ...ScanKeysOption) (*KeyStream, error) {
+func (s *KvStoreClient) Keys(ctx context.Context, opts ...ScanKeysOption) (_ *KeyStream, err error) {
+	ctx, span := tracing.StartClient(
+		ctx, "Store.Keys",
+		attribute.String("nitric.kv.store", s.name),
+	)
+	done := metrics.Start(metrics.Client, s.name, "Store.Keys")
+	defer func() {
+		tracing.End(span, err)
+		done(err)
+	}()
+
 	store := &v1.Store{
 		Name: s.name,
 	}
@@ -158,8 +207,17 @@ func (s *KvStoreClient) Keys(ctx context.Context, opts ...ScanKeysOption) (*KeyS
 	}, nil
 }
 
-func NewKvStoreClient(name string) (*KvStoreClient, error) {
-	conn, err := grpcx.GetConnection()
+// ClientOption configures a KvStoreClient, the options are defined in the clients package.
+type ClientOption = clients.Option
+
+// WithConnection and WithRetryPolicy set the connection the KvStoreClient uses and the policy its calls are retried with.
+var (
+	WithConnection  = clients.WithConnection
+	WithRetryPolicy = clients.WithRetryPolicy
+)
+
+func NewKvStoreClient(name string, opts ...ClientOption) (*KvStoreClient, error) {
+	conn, err := grpcx.ClientConnection(opts...)
 	if err != nil {
 		return nil, errors.NewWithCause(
 			codes.Unavailable,
@@ -168,10 +226,14 @@ func NewKvStoreClient(name string) (*KvStoreClient, error) {
 		)
 	}
 
+	return newKvStoreClient(name, conn), nil
+}
+
+func newKvStoreClient(name string, conn grpc.ClientConnInterface) *KvStoreClient {
 	client := v1.NewKvStoreClient(conn)
 
 	return &KvStoreClient{
 		name:     name,
 		kvClient: client,
-	}, nil
+	}
 }
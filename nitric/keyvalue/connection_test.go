@@ -0,0 +1,90 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyvalue
+
+import (
+	"context"
+	"net"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	v1 "github.com/nitrictech/nitric/core/pkg/proto/kvstore/v1"
+)
+
+// testKvStoreServer returns the same content for every key.
+type testKvStoreServer struct {
+	v1.UnimplementedKvStoreServer
+	content map[string]interface{}
+}
+
+func (t *testKvStoreServer) GetValue(ctx context.Context, req *v1.KvStoreGetValueRequest) (*v1.KvStoreGetValueResponse, error) {
+	content, err := structpb.NewStruct(t.content)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.KvStoreGetValueResponse{
+		Value: &v1.Value{Ref: req.Ref, Content: content},
+	}, nil
+}
+
+var _ = Describe("NewKvStoreClient", func() {
+	When("created with a connection", func() {
+		var (
+			srv  *grpc.Server
+			conn *grpc.ClientConn
+		)
+
+		BeforeEach(func() {
+			lis := bufconn.Listen(1024 * 1024)
+
+			srv = grpc.NewServer()
+			v1.RegisterKvStoreServer(srv, &testKvStoreServer{
+				content: map[string]interface{}{"hello": "world"},
+			})
+			go func() {
+				_ = srv.Serve(lis)
+			}()
+
+			var err error
+			conn, err = grpc.NewClient("passthrough:///bufnet",
+				grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+					return lis.DialContext(ctx)
+				}),
+				grpc.WithTransportCredentials(insecure.NewCredentials()),
+			)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			conn.Close()
+			srv.Stop()
+		})
+
+		It("should use the connection", func() {
+			client, err := NewKvStoreClient("store", WithConnection(conn))
+			Expect(err).ToNot(HaveOccurred())
+
+			value, err := client.Get(context.Background(), "key")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(map[string]interface{}{"hello": "world"}))
+		})
+	})
+})
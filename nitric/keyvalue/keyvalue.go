@@ -42,14 +42,27 @@ type kvstore struct {
 	registerChan <-chan workers.RegisterResult
 }
 
+type KvStoreOption func(*kvstore)
+
+// WithManager - Declare the key/value store with the given manager instead of the default manager
+func WithManager(manager *workers.Manager) KvStoreOption {
+	return func(k *kvstore) {
+		k.manager = manager
+	}
+}
+
 // NewKv - Create a new Key/Value store resource
-func NewKv(name string) *kvstore {
+func NewKv(name string, opts ...KvStoreOption) *kvstore {
 	kvstore := &kvstore{
 		name:         name,
 		manager:      workers.GetDefaultManager(),
 		registerChan: make(chan workers.RegisterResult),
 	}
 
+	for _, opt := range opts {
+		opt(kvstore)
+	}
+
 	kvstore.registerChan = kvstore.manager.RegisterResource(&v1.ResourceDeclareRequest{
 		Id: &v1.ResourceIdentifier{
 			Type: v1.ResourceType_KeyValueStore,
@@ -91,10 +104,10 @@ func (k *kvstore) Allow(permission KvStorePermission, permissions ...KvStorePerm
 		panic(err)
 	}
 
-	client, err := NewKvStoreClient(k.name)
+	conn, err := k.manager.Connection()
 	if err != nil {
 		panic(err)
 	}
 
-	return client
+	return newKvStoreClient(k.name, conn)
 }
@@ -0,0 +1,85 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics measures the handlers run by workers and the calls made by clients to the Nitric server.
+//
+// Measurements are passed to a Recorder set with SetRecorder, see the prommetrics and otelmetrics packages
+// for Recorders backed by Prometheus and OpenTelemetry.
+package metrics
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Kind is the kind of operation being measured.
+type Kind string
+
+const (
+	// Worker is a handler called by a worker, e.g. for an API request or topic message.
+	Worker Kind = "worker"
+	// Client is a call made by a client to the Nitric server, e.g. a KV get or a topic publish.
+	Client Kind = "client"
+)
+
+// Operation identifies what is being measured.
+type Operation struct {
+	// Kind is the kind of the operation.
+	Kind Kind
+	// Name is the name of the worker, as added to the workers.Manager, or the name of the resource a client is for.
+	Name string
+	// Method is the operation performed, e.g. "Store.Get". It is "handle" for workers.
+	Method string
+}
+
+// Recorder records metrics for operations, it must be safe for concurrent use.
+type Recorder interface {
+	// Start is called when an operation starts.
+	Start(op Operation)
+	// End is called when an operation ends, with how long it took and the error it failed with, if any.
+	End(op Operation, duration time.Duration, err error)
+}
+
+type recorderHolder struct {
+	recorder Recorder
+}
+
+var current atomic.Pointer[recorderHolder]
+
+// SetRecorder - Sets the Recorder used by all workers and clients, passing nil disables metrics
+func SetRecorder(recorder Recorder) {
+	if recorder == nil {
+		current.Store(nil)
+		return
+	}
+
+	current.Store(&recorderHolder{recorder: recorder})
+}
+
+// Start starts measuring an operation, returning a function to call with its result when it ends.
+func Start(kind Kind, name string, method string) func(err error) {
+	holder := current.Load()
+	if holder == nil {
+		return func(error) {}
+	}
+
+	op := Operation{Kind: kind, Name: name, Method: method}
+	start := time.Now()
+
+	holder.recorder.Start(op)
+
+	return func(err error) {
+		holder.recorder.End(op, time.Since(start), err)
+	}
+}
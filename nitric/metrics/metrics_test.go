@@ -0,0 +1,100 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics_test
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/nitrictech/go-sdk/nitric/metrics"
+)
+
+type recorded struct {
+	op       metrics.Operation
+	duration time.Duration
+	err      error
+}
+
+type fakeRecorder struct {
+	mu      sync.Mutex
+	started []metrics.Operation
+	ended   []recorded
+}
+
+func (f *fakeRecorder) Start(op metrics.Operation) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.started = append(f.started, op)
+}
+
+func (f *fakeRecorder) End(op metrics.Operation, duration time.Duration, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.ended = append(f.ended, recorded{op: op, duration: duration, err: err})
+}
+
+var _ = Describe("Metrics", func() {
+	AfterEach(func() {
+		metrics.SetRecorder(nil)
+	})
+
+	When("no recorder is set", func() {
+		It("should do nothing", func() {
+			done := metrics.Start(metrics.Client, "store", "Store.Get")
+			Expect(done).ToNot(BeNil())
+
+			done(errors.New("failed"))
+		})
+	})
+
+	When("a recorder is set", func() {
+		var recorder *fakeRecorder
+
+		BeforeEach(func() {
+			recorder = &fakeRecorder{}
+			metrics.SetRecorder(recorder)
+		})
+
+		It("should record the start of the operation", func() {
+			metrics.Start(metrics.Client, "store", "Store.Get")
+
+			Expect(recorder.started).To(Equal([]metrics.Operation{{
+				Kind:   metrics.Client,
+				Name:   "store",
+				Method: "Store.Get",
+			}}))
+			Expect(recorder.ended).To(BeEmpty())
+		})
+
+		It("should record the duration and error when the operation ends", func() {
+			err := errors.New("failed")
+
+			done := metrics.Start(metrics.Worker, "SubscriptionWorker:updates", "handle")
+			time.Sleep(time.Millisecond)
+			done(err)
+
+			Expect(recorder.ended).To(HaveLen(1))
+			Expect(recorder.ended[0].op).To(Equal(recorder.started[0]))
+			Expect(recorder.ended[0].duration).To(BeNumerically(">=", time.Millisecond))
+			Expect(recorder.ended[0].err).To(Equal(err))
+		})
+	})
+})
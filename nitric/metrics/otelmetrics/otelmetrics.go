@@ -0,0 +1,116 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otelmetrics provides a metrics.Recorder which records to OpenTelemetry.
+package otelmetrics
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/nitrictech/go-sdk/nitric/errors"
+	"github.com/nitrictech/go-sdk/nitric/metrics"
+)
+
+const instrumentationName = "github.com/nitrictech/go-sdk"
+
+// Recorder records metrics for Nitric workers and clients to OpenTelemetry.
+type Recorder struct {
+	requests metric.Int64Counter
+	errors   metric.Int64Counter
+	duration metric.Float64Histogram
+	inFlight metric.Int64UpDownCounter
+}
+
+var _ metrics.Recorder = (*Recorder)(nil)
+
+// New creates a Recorder using a meter from the provider.
+// If provider is nil the global meter provider is used.
+func New(provider metric.MeterProvider) (*Recorder, error) {
+	if provider == nil {
+		provider = otel.GetMeterProvider()
+	}
+
+	meter := provider.Meter(instrumentationName)
+
+	requests, err := meter.Int64Counter("nitric.requests",
+		metric.WithDescription("Total number of handled requests and client calls."),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	errs, err := meter.Int64Counter("nitric.request.errors",
+		metric.WithDescription("Total number of handled requests and client calls that failed."),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	duration, err := meter.Float64Histogram("nitric.request.duration",
+		metric.WithDescription("Duration of handled requests and client calls."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	inFlight, err := meter.Int64UpDownCounter("nitric.requests.in_flight",
+		metric.WithDescription("Number of requests being handled and client calls in progress."),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Recorder{
+		requests: requests,
+		errors:   errs,
+		duration: duration,
+		inFlight: inFlight,
+	}, nil
+}
+
+func attributes(op metrics.Operation) attribute.Set {
+	return attribute.NewSet(
+		attribute.String("nitric.kind", string(op.Kind)),
+		attribute.String("nitric.name", op.Name),
+		attribute.String("nitric.method", op.Method),
+	)
+}
+
+// Start implements metrics.Recorder.
+func (r *Recorder) Start(op metrics.Operation) {
+	r.inFlight.Add(context.Background(), 1, metric.WithAttributeSet(attributes(op)))
+}
+
+// End implements metrics.Recorder.
+func (r *Recorder) End(op metrics.Operation, duration time.Duration, err error) {
+	ctx := context.Background()
+	attrs := metric.WithAttributeSet(attributes(op))
+
+	r.inFlight.Add(ctx, -1, attrs)
+	r.requests.Add(ctx, 1, attrs)
+	r.duration.Record(ctx, duration.Seconds(), attrs)
+
+	if err != nil {
+		r.errors.Add(ctx, 1, attrs, metric.WithAttributes(attribute.String("nitric.error.code", errors.Code(err).String())))
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otelmetrics_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/nitrictech/go-sdk/nitric/errors"
+	"github.com/nitrictech/go-sdk/nitric/errors/codes"
+	"github.com/nitrictech/go-sdk/nitric/metrics"
+	"github.com/nitrictech/go-sdk/nitric/metrics/otelmetrics"
+)
+
+var _ = Describe("Recorder", func() {
+	var (
+		reader   *sdkmetric.ManualReader
+		recorder *otelmetrics.Recorder
+		op       metrics.Operation
+	)
+
+	BeforeEach(func() {
+		var err error
+
+		reader = sdkmetric.NewManualReader()
+		recorder, err = otelmetrics.New(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+		Expect(err).ToNot(HaveOccurred())
+
+		op = metrics.Operation{Kind: metrics.Client, Name: "store", Method: "Store.Get"}
+	})
+
+	It("should track requests in flight", func() {
+		recorder.Start(op)
+
+		inFlight := collect(reader)["nitric.requests.in_flight"].Data.(metricdata.Sum[int64])
+		Expect(inFlight.DataPoints).To(HaveLen(1))
+		Expect(inFlight.DataPoints[0].Value).To(Equal(int64(1)))
+
+		recorder.End(op, time.Second, nil)
+
+		inFlight = collect(reader)["nitric.requests.in_flight"].Data.(metricdata.Sum[int64])
+		Expect(inFlight.DataPoints).To(HaveLen(1))
+		Expect(inFlight.DataPoints[0].Value).To(Equal(int64(0)))
+	})
+
+	It("should record a success and a failure", func() {
+		recorder.Start(op)
+		recorder.End(op, 100*time.Millisecond, nil)
+
+		recorder.Start(op)
+		recorder.End(op, 200*time.Millisecond, errors.New(codes.NotFound, "not found"))
+
+		collected := collect(reader)
+
+		By("counting both requests")
+		requests := collected["nitric.requests"].Data.(metricdata.Sum[int64])
+		Expect(requests.DataPoints).To(HaveLen(1))
+		Expect(requests.DataPoints[0].Value).To(Equal(int64(2)))
+		Expect(requests.DataPoints[0].Attributes.Equals(attributesOf(op))).To(BeTrue())
+
+		By("counting only the failure as an error, by code")
+		errs := collected["nitric.request.errors"].Data.(metricdata.Sum[int64])
+		Expect(errs.DataPoints).To(HaveLen(1))
+		Expect(errs.DataPoints[0].Value).To(Equal(int64(1)))
+
+		code, ok := errs.DataPoints[0].Attributes.Value("nitric.error.code")
+		Expect(ok).To(BeTrue())
+		Expect(code.AsString()).To(Equal(codes.NotFound.String()))
+
+		By("observing the duration of both requests in seconds")
+		duration := collected["nitric.request.duration"].Data.(metricdata.Histogram[float64])
+		Expect(collected["nitric.request.duration"].Unit).To(Equal("s"))
+		Expect(duration.DataPoints).To(HaveLen(1))
+		Expect(duration.DataPoints[0].Count).To(Equal(uint64(2)))
+		Expect(duration.DataPoints[0].Sum).To(BeNumerically("~", 0.3, 1e-9))
+
+		By("leaving no requests in flight")
+		inFlight := collected["nitric.requests.in_flight"].Data.(metricdata.Sum[int64])
+		Expect(inFlight.DataPoints).To(HaveLen(1))
+		Expect(inFlight.DataPoints[0].Value).To(Equal(int64(0)))
+	})
+})
+
+// collect returns the metrics gathered by the reader, keyed by name.
+func collect(reader *sdkmetric.ManualReader) map[string]metricdata.Metrics {
+	var rm metricdata.ResourceMetrics
+	Expect(reader.Collect(context.Background(), &rm)).To(Succeed())
+
+	collected := map[string]metricdata.Metrics{}
+	for _, scope := range rm.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			collected[m.Name] = m
+		}
+	}
+
+	return collected
+}
+
+// attributesOf returns the attributes the recorder attaches to the operation.
+func attributesOf(op metrics.Operation) *attribute.Set {
+	set := attribute.NewSet(
+		attribute.String("nitric.kind", string(op.Kind)),
+		attribute.String("nitric.name", op.Name),
+		attribute.String("nitric.method", op.Method),
+	)
+
+	return &set
+}
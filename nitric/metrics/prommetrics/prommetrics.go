@@ -0,0 +1,122 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package prommetrics provides a metrics.Recorder which records to Prometheus.
+package prommetrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/nitrictech/go-sdk/nitric/errors"
+	"github.com/nitrictech/go-sdk/nitric/metrics"
+)
+
+var labels = []string{"kind", "name", "method"}
+
+// Recorder records metrics for Nitric workers and clients to Prometheus.
+type Recorder struct {
+	namespace string
+	buckets   []float64
+
+	requests *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	inFlight *prometheus.GaugeVec
+}
+
+var _ metrics.Recorder = (*Recorder)(nil)
+
+type Option func(*Recorder)
+
+// WithNamespace - Sets the namespace prefixed to the metric names, defaults to "nitric"
+func WithNamespace(namespace string) Option {
+	return func(r *Recorder) {
+		r.namespace = namespace
+	}
+}
+
+// WithBuckets - Sets the buckets of the request duration histogram, in seconds, defaults to prometheus.DefBuckets
+func WithBuckets(buckets []float64) Option {
+	return func(r *Recorder) {
+		r.buckets = buckets
+	}
+}
+
+// New creates a Recorder, registering its metrics with the registerer.
+// If registerer is nil the metrics are registered with prometheus.DefaultRegisterer.
+func New(registerer prometheus.Registerer, opts ...Option) (*Recorder, error) {
+	if registerer == nil {
+		registerer = prometheus.DefaultRegisterer
+	}
+
+	r := &Recorder{
+		namespace: "nitric",
+		buckets:   prometheus.DefBuckets,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	r.requests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: r.namespace,
+		Name:      "requests_total",
+		Help:      "Total number of handled requests and client calls.",
+	}, labels)
+
+	r.errors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: r.namespace,
+		Name:      "request_errors_total",
+		Help:      "Total number of handled requests and client calls that failed.",
+	}, append(labels, "code"))
+
+	r.duration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: r.namespace,
+		Name:      "request_duration_seconds",
+		Help:      "Duration of handled requests and client calls in seconds.",
+		Buckets:   r.buckets,
+	}, labels)
+
+	r.inFlight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: r.namespace,
+		Name:      "requests_in_flight",
+		Help:      "Number of requests being handled and client calls in progress.",
+	}, labels)
+
+	for _, collector := range []prometheus.Collector{r.requests, r.errors, r.duration, r.inFlight} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// Start implements metrics.Recorder.
+func (r *Recorder) Start(op metrics.Operation) {
+	r.inFlight.WithLabelValues(string(op.Kind), op.Name, op.Method).Inc()
+}
+
+// End implements metrics.Recorder.
+func (r *Recorder) End(op metrics.Operation, duration time.Duration, err error) {
+	r.inFlight.WithLabelValues(string(op.Kind), op.Name, op.Method).Dec()
+	r.requests.WithLabelValues(string(op.Kind), op.Name, op.Method).Inc()
+	r.duration.WithLabelValues(string(op.Kind), op.Name, op.Method).Observe(duration.Seconds())
+
+	if err != nil {
+		r.errors.WithLabelValues(string(op.Kind), op.Name, op.Method, errors.Code(err).String()).Inc()
+	}
+}
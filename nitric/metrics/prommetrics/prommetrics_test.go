@@ -0,0 +1,132 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prommetrics_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/nitrictech/go-sdk/nitric/errors"
+	"github.com/nitrictech/go-sdk/nitric/errors/codes"
+	"github.com/nitrictech/go-sdk/nitric/metrics"
+	"github.com/nitrictech/go-sdk/nitric/metrics/prommetrics"
+)
+
+var _ = Describe("Recorder", func() {
+	var (
+		registry *prometheus.Registry
+		recorder *prommetrics.Recorder
+		op       metrics.Operation
+	)
+
+	BeforeEach(func() {
+		var err error
+
+		registry = prometheus.NewRegistry()
+		recorder, err = prommetrics.New(registry)
+		Expect(err).ToNot(HaveOccurred())
+
+		op = metrics.Operation{Kind: metrics.Client, Name: "store", Method: "Store.Get"}
+	})
+
+	It("should track requests in flight", func() {
+		recorder.Start(op)
+
+		Expect(gaugeValue(registry, "nitric_requests_in_flight")).To(Equal(1.0))
+
+		recorder.End(op, time.Second, nil)
+
+		Expect(gaugeValue(registry, "nitric_requests_in_flight")).To(Equal(0.0))
+	})
+
+	It("should count requests and observe their duration", func() {
+		recorder.Start(op)
+		recorder.End(op, time.Second, nil)
+
+		count, err := testutil.GatherAndCount(registry, "nitric_requests_total", "nitric_request_duration_seconds", "nitric_request_errors_total")
+		Expect(err).ToNot(HaveOccurred())
+		// one series each for the requests counter and duration histogram, none for errors
+		Expect(count).To(Equal(2))
+	})
+
+	It("should count errors by code", func() {
+		recorder.Start(op)
+		recorder.End(op, time.Second, errors.New(codes.NotFound, "not found"))
+
+		families, err := registry.Gather()
+		Expect(err).ToNot(HaveOccurred())
+
+		for _, family := range families {
+			if family.GetName() != "nitric_request_errors_total" {
+				continue
+			}
+
+			Expect(family.GetMetric()).To(HaveLen(1))
+			Expect(family.GetMetric()[0].GetCounter().GetValue()).To(Equal(1.0))
+
+			labels := map[string]string{}
+			for _, label := range family.GetMetric()[0].GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			Expect(labels).To(Equal(map[string]string{
+				"kind":   "client",
+				"name":   "store",
+				"method": "Store.Get",
+				"code":   codes.NotFound.String(),
+			}))
+
+			return
+		}
+
+		Fail("nitric_request_errors_total was not gathered")
+	})
+
+	It("should fail when the metrics are already registered", func() {
+		_, err := prommetrics.New(registry)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should prefix metrics with the namespace", func() {
+		registry := prometheus.NewRegistry()
+		recorder, err := prommetrics.New(registry, prommetrics.WithNamespace("custom"))
+		Expect(err).ToNot(HaveOccurred())
+
+		recorder.Start(op)
+
+		count, err := testutil.GatherAndCount(registry, "custom_requests_in_flight")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(count).To(Equal(1))
+	})
+})
+
+// gaugeValue returns the value of the gauge with the given name gathered from the registry.
+func gaugeValue(registry *prometheus.Registry, name string) float64 {
+	families, err := registry.Gather()
+	Expect(err).ToNot(HaveOccurred())
+
+	for _, family := range families {
+		if family.GetName() == name {
+			Expect(family.GetMetric()).To(HaveLen(1))
+			return family.GetMetric()[0].GetGauge().GetValue()
+		}
+	}
+
+	Fail(name + " was not gathered")
+	return 0
+}
@@ -16,15 +16,19 @@ package nitric
 
 import (
 	"context"
-	"fmt"
+	"log/slog"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	"github.com/nitrictech/go-sdk/internal/tracing"
 	"github.com/nitrictech/go-sdk/nitric/apis"
 	"github.com/nitrictech/go-sdk/nitric/batch"
 	"github.com/nitrictech/go-sdk/nitric/keyvalue"
+	"github.com/nitrictech/go-sdk/nitric/metrics"
 	"github.com/nitrictech/go-sdk/nitric/queues"
+	"github.com/nitrictech/go-sdk/nitric/retry"
 	"github.com/nitrictech/go-sdk/nitric/schedules"
 	"github.com/nitrictech/go-sdk/nitric/secrets"
 	"github.com/nitrictech/go-sdk/nitric/sql"
@@ -47,6 +51,8 @@ var (
 	NewJob         = batch.NewJob
 )
 
+// Run starts the workers for all declared resources and blocks until a SIGTERM or SIGINT is received,
+// then shuts down gracefully. Run panics if a worker fails, use RunContext to handle errors instead.
 func Run() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -55,13 +61,82 @@ func Run() {
 	signal.Notify(sigChan, syscall.SIGTERM, syscall.SIGINT)
 
 	go func() {
-		<-sigChan
-		fmt.Printf("Received signal, shutting down...\n")
+		sig := <-sigChan
+		workers.GetDefaultManager().Logger().Info("received signal, shutting down", "signal", sig.String())
 		cancel()
 	}()
 
-	err := workers.GetDefaultManager().Run(ctx)
+	err := RunContext(ctx)
 	if err != nil {
 		panic(err)
 	}
 }
+
+// RunContext starts the workers for all declared resources and blocks until they stop or ctx is cancelled.
+//
+// When ctx is cancelled in-flight messages are handled before the workers are stopped, up to the shutdown timeout,
+// and the shutdown hooks are run. Errors from the workers and shutdown hooks are returned.
+func RunContext(ctx context.Context) error {
+	return workers.GetDefaultManager().Run(ctx)
+}
+
+// OnStart registers a hook run before the workers are started, e.g. to open connection pools.
+// If the hook returns an error the workers aren't started and the error is returned from Run.
+func OnStart(hook workers.Hook) {
+	workers.GetDefaultManager().OnStart(hook)
+}
+
+// OnReady registers a hook run once every worker has been registered with the Nitric server, e.g. to warm caches.
+func OnReady(hook workers.Hook) {
+	workers.GetDefaultManager().OnReady(hook)
+}
+
+// OnShutdown registers a hook run once the workers have stopped, e.g. to flush telemetry or close connections.
+func OnShutdown(hook workers.Hook) {
+	workers.GetDefaultManager().OnShutdown(hook)
+}
+
+// SetShutdownTimeout sets how long to wait for in-flight messages and shutdown hooks once shutdown starts.
+func SetShutdownTimeout(timeout time.Duration) {
+	workers.GetDefaultManager().SetShutdownTimeout(timeout)
+}
+
+// SetStartupTimeout sets how long to wait for the Nitric server to become reachable before declaring resources.
+func SetStartupTimeout(timeout time.Duration) {
+	workers.GetDefaultManager().SetStartupTimeout(timeout)
+}
+
+// SetLogger sets the logger used by the SDK, defaults to slog.Default().
+// It applies to every manager that hasn't set its own logger with workers.Manager.SetLogger.
+func SetLogger(logger *slog.Logger) {
+	workers.SetDefaultLogger(logger)
+}
+
+// SetMetricsRecorder sets the recorder for worker and client metrics, see the metrics package for Prometheus and OpenTelemetry recorders.
+func SetMetricsRecorder(recorder metrics.Recorder) {
+	metrics.SetRecorder(recorder)
+}
+
+// SetMessageTracePropagation sets whether the trace context is added to the payload of messages published to topics, queues and jobs,
+// so their handlers continue the publisher's trace. Messages have no metadata to carry it, so it's disabled by default.
+//
+// When enabled the trace context is added under the reserved "_nitric_trace_context" payload key, which is removed before
+// the message reaches handlers using this SDK. Consumers using other SDKs or languages will see the key in the message data.
+func SetMessageTracePropagation(enabled bool) {
+	tracing.SetMessagePropagation(enabled)
+}
+
+// SetRetryPolicy sets the policy used to retry calls made by clients that don't set a policy with their options, see the retry package.
+func SetRetryPolicy(policy retry.Policy) {
+	retry.SetDefault(policy)
+}
+
+// SetOffline sets whether resources are only recorded in the manifest, without a Nitric server, see workers.Manager.SetOffline.
+func SetOffline(offline bool) {
+	workers.GetDefaultManager().SetOffline(offline)
+}
+
+// Manifest returns the manifest of the resources, policies and workers declared by the application.
+func Manifest() *workers.Manifest {
+	return workers.GetDefaultManager().Manifest()
+}
@@ -17,9 +17,16 @@ package queues
 import (
 	"context"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+
 	grpcx "github.com/nitrictech/go-sdk/internal/grpc"
+	"github.com/nitrictech/go-sdk/internal/tracing"
+	"github.com/nitrictech/go-sdk/nitric/clients"
 	"github.com/nitrictech/go-sdk/nitric/errors"
 	"github.com/nitrictech/go-sdk/nitric/errors/codes"
+	"github.com/nitrictech/go-sdk/nitric/metrics"
 	v1 "github.com/nitrictech/nitric/core/pkg/proto/queues/v1"
 )
 
@@ -42,7 +49,19 @@ func (q *QueueClient) Name() string {
 	return q.name
 }
 
-func (q *QueueClient) Dequeue(ctx context.Context, depth int) ([]ReceivedMessage, error) {
+func (q *QueueClient) Dequeue(ctx context.Context, depth int) (_ []ReceivedMessage, err error) {
+	ctx, span := tracing.StartClient(
+		ctx, "Queue.Dequeue",
+		attribute.String("messaging.system", "nitric"),
+		attribute.String("messaging.destination.name", q.name),
+		attribute.String("messaging.operation", "receive"),
+	)
+	done := metrics.Start(metrics.Client, q.name, "Queue.Dequeue")
+	defer func() {
+		tracing.End(span, err)
+		done(err)
+	}()
+
 	if depth < 1 {
 		return nil, errors.New(codes.InvalidArgument, "Queue.Dequeue: depth cannot be less than 1")
 	}
@@ -58,22 +77,42 @@ func (q *QueueClient) Dequeue(ctx context.Context, depth int) ([]ReceivedMessage
 	rts := make([]ReceivedMessage, len(r.GetMessages()))
 
 	for i, message := range r.GetMessages() {
+		msg := wireToMessage(message.GetMessage())
+
+		// Link the message to the trace it was enqueued in, if any
+		if producer := trace.SpanContextFromContext(tracing.ExtractMessage(ctx, msg)); producer.IsValid() {
+			span.AddLink(trace.Link{SpanContext: producer})
+		}
+
 		rts[i] = &leasedMessage{
 			queueName:   q.name,
 			queueClient: q.queueClient,
 			leaseId:     message.GetLeaseId(),
-			message:     wireToMessage(message.GetMessage()),
+			message:     msg,
 		}
 	}
 
 	return rts, nil
 }
 
-func (q *QueueClient) Enqueue(ctx context.Context, messages []map[string]interface{}) ([]*FailedMessage, error) {
+func (q *QueueClient) Enqueue(ctx context.Context, messages []map[string]interface{}) (_ []*FailedMessage, err error) {
+	ctx, span := tracing.StartClient(
+		ctx, "Queue.Enqueue",
+		attribute.String("messaging.system", "nitric"),
+		attribute.String("messaging.destination.name", q.name),
+		attribute.String("messaging.operation", "publish"),
+		attribute.Int("messaging.batch.message_count", len(messages)),
+	)
+	done := metrics.Start(metrics.Client, q.name, "Queue.Enqueue")
+	defer func() {
+		tracing.End(span, err)
+		done(err)
+	}()
+
 	// Convert SDK Message objects to gRPC Message objects
 	wireMessages := make([]*v1.QueueMessage, len(messages))
 	for i, message := range messages {
-		wireMessage, err := messageToWire(message)
+		wireMessage, err := messageToWire(tracing.InjectMessage(ctx, message))
 		if err != nil {
 			return nil, errors.NewWithCause(
 				codes.Internal,
@@ -96,8 +135,11 @@ func (q *QueueClient) Enqueue(ctx context.Context, messages []map[string]interfa
 	// Convert the gRPC Failed Messages to SDK Failed Message objects
 	failedMessages := make([]*FailedMessage, len(res.GetFailedMessages()))
 	for i, failedMessage := range res.GetFailedMessages() {
+		msg := wireToMessage(failedMessage.GetMessage())
+		delete(msg, tracing.TraceContextKey)
+
 		failedMessages[i] = &FailedMessage{
-			Message: wireToMessage(failedMessage.GetMessage()),
+			Message: msg,
 			Reason:  failedMessage.GetDetails(),
 		}
 	}
@@ -105,8 +147,17 @@ func (q *QueueClient) Enqueue(ctx context.Context, messages []map[string]interfa
 	return failedMessages, nil
 }
 
-func NewQueueClient(name string) (*QueueClient, error) {
-	conn, err := grpcx.GetConnection()
+// ClientOption configures a QueueClient, the options are defined in the clients package.
+type ClientOption = clients.Option
+
+// WithConnection and WithRetryPolicy set the connection the QueueClient uses and the policy its calls are retried with, Enqueue and Dequeue are only retried if the policy sets NonIdempotent.
+var (
+	WithConnection  = clients.WithConnection
+	WithRetryPolicy = clients.WithRetryPolicy
+)
+
+func NewQueueClient(name string, opts ...ClientOption) (*QueueClient, error) {
+	conn, err := grpcx.ClientConnection(opts...)
 	if err != nil {
 		return nil, errors.NewWithCause(
 			codes.Unavailable,
@@ -115,10 +166,14 @@ func NewQueueClient(name string) (*QueueClient, error) {
 		)
 	}
 
+	return newQueueClient(name, conn), nil
+}
+
+func newQueueClient(name string, conn grpc.ClientConnInterface) *QueueClient {
 	queueClient := v1.NewQueuesClient(conn)
 
 	return &QueueClient{
 		name:        name,
 		queueClient: queueClient,
-	}, nil
+	}
 }
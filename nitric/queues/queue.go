@@ -41,14 +41,27 @@ type queue struct {
 	registerChan <-chan workers.RegisterResult
 }
 
+type QueueOption func(*queue)
+
+// WithManager - Declare the queue with the given manager instead of the default manager
+func WithManager(manager *workers.Manager) QueueOption {
+	return func(q *queue) {
+		q.manager = manager
+	}
+}
+
 // NewQueue - Create a new Queue resource
-func NewQueue(name string) *queue {
+func NewQueue(name string, opts ...QueueOption) *queue {
 	queue := &queue{
 		name:         name,
 		manager:      workers.GetDefaultManager(),
 		registerChan: make(chan workers.RegisterResult),
 	}
 
+	for _, opt := range opts {
+		opt(queue)
+	}
+
 	queue.registerChan = queue.manager.RegisterResource(&v1.ResourceDeclareRequest{
 		Id: &v1.ResourceIdentifier{
 			Type: v1.ResourceType_Queue,
@@ -87,10 +100,10 @@ func (q *queue) Allow(permission QueuePermission, permissions ...QueuePermission
 		panic(err)
 	}
 
-	client, err := NewQueueClient(q.name)
+	conn, err := q.manager.Connection()
 	if err != nil {
 		panic(err)
 	}
 
-	return client
+	return newQueueClient(q.name, conn)
 }
@@ -0,0 +1,120 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package retry configures how the SDK's clients retry calls to the Nitric server that fail with transient errors.
+//
+// Idempotent operations, such as KvStoreClient.Get and Set, BucketClient.Read and Delete, and SecretClient.Access,
+// are retried with the default policy. Operations that may be applied more than once when retried, such as
+// TopicClient.Publish and QueueClient.Enqueue, are only retried when the policy sets NonIdempotent.
+//
+// The policy is taken from the call's context, see WithPolicy, then the client's options, then the default policy.
+package retry
+
+import (
+	"context"
+	"math"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/nitrictech/go-sdk/nitric/errors/codes"
+)
+
+// Policy - Sets how calls that fail with a retryable code are retried
+type Policy struct {
+	// MaxAttempts is the number of times a call is made, including the first attempt. Calls aren't retried if it's less than 2.
+	MaxAttempts int
+
+	// InitialBackoff is the time to wait before the first retry.
+	InitialBackoff time.Duration
+
+	// MaxBackoff is the longest time to wait between retries.
+	MaxBackoff time.Duration
+
+	// BackoffMultiplier is applied to the backoff after each retry.
+	BackoffMultiplier float64
+
+	// RetryableCodes are the error codes that are retried.
+	RetryableCodes []codes.Code
+
+	// NonIdempotent also retries operations that may be applied more than once when retried, e.g. TopicClient.Publish and QueueClient.Enqueue.
+	NonIdempotent bool
+}
+
+// DefaultPolicy returns the policy used unless another is set, it makes 3 attempts and retries Unavailable and DeadlineExceeded errors.
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxAttempts:       3,
+		InitialBackoff:    100 * time.Millisecond,
+		MaxBackoff:        2 * time.Second,
+		BackoffMultiplier: 2,
+		RetryableCodes:    []codes.Code{codes.Unavailable, codes.DeadlineExceeded},
+	}
+}
+
+// Disabled returns a policy that doesn't retry calls.
+func Disabled() Policy {
+	return Policy{MaxAttempts: 1}
+}
+
+// Retryable returns true if errors with the code are retried.
+func (p Policy) Retryable(code codes.Code) bool {
+	return slices.Contains(p.RetryableCodes, code)
+}
+
+// Backoff returns the time to wait before the given retry, starting from 1 for the first retry.
+func (p Policy) Backoff(retry int) time.Duration {
+	multiplier := p.BackoffMultiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	backoff := float64(p.InitialBackoff) * math.Pow(multiplier, float64(retry-1))
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		return p.MaxBackoff
+	}
+
+	return time.Duration(backoff)
+}
+
+var defaultPolicy atomic.Pointer[Policy]
+
+// SetDefault - Sets the policy used by clients that don't set a policy with their options
+func SetDefault(policy Policy) {
+	defaultPolicy.Store(&policy)
+}
+
+// Default returns the policy used by clients that don't set a policy with their options.
+func Default() Policy {
+	if policy := defaultPolicy.Load(); policy != nil {
+		return *policy
+	}
+
+	return DefaultPolicy()
+}
+
+type contextKey struct{}
+
+// WithPolicy returns a context that overrides the policy of calls made with it.
+//
+//	err := topic.Publish(retry.WithPolicy(ctx, retry.Policy{MaxAttempts: 5, NonIdempotent: true, ...}), message)
+func WithPolicy(ctx context.Context, policy Policy) context.Context {
+	return context.WithValue(ctx, contextKey{}, policy)
+}
+
+// FromContext returns the policy set on the context with WithPolicy.
+func FromContext(ctx context.Context) (Policy, bool) {
+	policy, ok := ctx.Value(contextKey{}).(Policy)
+	return policy, ok
+}
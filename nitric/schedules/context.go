@@ -14,9 +14,14 @@
 
 package schedules
 
-import schedulespb "github.com/nitrictech/nitric/core/pkg/proto/schedules/v1"
+import (
+	"github.com/nitrictech/go-sdk/internal/handlers"
+	schedulespb "github.com/nitrictech/nitric/core/pkg/proto/schedules/v1"
+)
 
 type Ctx struct {
+	handlers.RequestContext
+
 	id       string
 	Request  Request
 	Response *Response
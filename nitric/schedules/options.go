@@ -0,0 +1,38 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedules
+
+import "github.com/nitrictech/go-sdk/internal/handlers"
+
+type (
+	Handler       = handlers.Handler[Ctx]
+	Middleware    = handlers.Middleware[Ctx]
+	HandlerOption = handlers.Option[Ctx]
+)
+
+// WithMiddleware, WithTimeout and WithMaxInFlight configure the schedule handler: the middleware it's wrapped in,
+// the maximum duration of each call, and the number of schedule events handled concurrently, which defaults to 1.
+var (
+	WithMiddleware  = handlers.WithMiddleware[Ctx]
+	WithTimeout     = handlers.WithTimeout[Ctx]
+	WithMaxInFlight = handlers.WithMaxInFlight[Ctx]
+)
+
+// Chain wraps a schedule handler in middleware, and Compose combines middleware into one, e.g. to share a stack between schedules.
+// The first middleware is the outermost, so it is called first and returns last.
+var (
+	Chain   = handlers.Chain[Ctx]
+	Compose = handlers.Compose[Ctx]
+)
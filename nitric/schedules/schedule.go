@@ -31,7 +31,7 @@ type Schedule interface {
 	//	func(*schedules.Ctx)
 	//	func(*schedules.Ctx) error
 	//	Handler[schedules.Ctx]
-	Cron(cron string, handler interface{})
+	Cron(cron string, handler interface{}, opts ...HandlerOption)
 
 	// Run a function at a certain interval defined by the rate. The rate is e.g. '7 days'. All rates accept a number and a frequency. Valid frequencies are 'days', 'hours' or 'minutes'.
 	// Valid function signatures for handler are:
@@ -41,7 +41,7 @@ type Schedule interface {
 	//	func(*schedules.Ctx)
 	//	func(*schedules.Ctx) error
 	//	Handler[schedules.Ctx]
-	Every(rate string, handler interface{})
+	Every(rate string, handler interface{}, opts ...HandlerOption)
 }
 
 type schedule struct {
@@ -51,15 +51,32 @@ type schedule struct {
 
 var _ Schedule = (*schedule)(nil)
 
+type ScheduleOption func(*schedule)
+
+// WithManager - Declare the schedule with the given manager instead of the default manager
+func WithManager(manager *workers.Manager) ScheduleOption {
+	return func(s *schedule) {
+		s.manager = manager
+	}
+}
+
 // NewSchedule - Create a new Schedule resource
-func NewSchedule(name string) Schedule {
-	return &schedule{
+func NewSchedule(name string, opts ...ScheduleOption) Schedule {
+	schedule := &schedule{
 		name:    name,
 		manager: workers.GetDefaultManager(),
 	}
+
+	for _, opt := range opts {
+		opt(schedule)
+	}
+
+	return schedule
 }
 
-func (s *schedule) Cron(cron string, handler interface{}) {
+func (s *schedule) Cron(cron string, handler interface{}, opts ...HandlerOption) {
+	options := handlers.NewOptions(opts...)
+
 	scheduleCron := &schedulespb.ScheduleCron{
 		Expression: cron,
 	}
@@ -76,19 +93,24 @@ func (s *schedule) Cron(cron string, handler interface{}) {
 		panic(err)
 	}
 
-	opts := &scheduleWorkerOpts{
+	workerOpts := &scheduleWorkerOpts{
+		Manager:             s.manager,
 		RegistrationRequest: registrationRequest,
-		Handler:             typedHandler,
+		Handler:             handlers.Chain(typedHandler, options.Middleware...),
+		Timeout:             options.Timeout,
+		MaxInFlight:         options.MaxInFlight,
 	}
 
-	worker := newScheduleWorker(opts)
+	worker := newScheduleWorker(workerOpts)
 	s.manager.AddWorker("IntervalWorkerCron:"+strings.Join([]string{
 		s.name,
 		cron,
 	}, "-"), worker)
 }
 
-func (s *schedule) Every(rate string, handler interface{}) {
+func (s *schedule) Every(rate string, handler interface{}, opts ...HandlerOption) {
+	options := handlers.NewOptions(opts...)
+
 	scheduleEvery := &schedulespb.ScheduleEvery{
 		Rate: rate,
 	}
@@ -105,12 +127,15 @@ func (s *schedule) Every(rate string, handler interface{}) {
 		panic(err)
 	}
 
-	opts := &scheduleWorkerOpts{
+	workerOpts := &scheduleWorkerOpts{
+		Manager:             s.manager,
 		RegistrationRequest: registrationRequest,
-		Handler:             typedHandler,
+		Handler:             handlers.Chain(typedHandler, options.Middleware...),
+		Timeout:             options.Timeout,
+		MaxInFlight:         options.MaxInFlight,
 	}
 
-	worker := newScheduleWorker(opts)
+	worker := newScheduleWorker(workerOpts)
 	s.manager.AddWorker("IntervalWorkerEvery:"+strings.Join([]string{
 		s.name,
 		rate,
@@ -17,9 +17,14 @@ package schedules
 import (
 	"context"
 	errorsstd "errors"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
 
-	grpcx "github.com/nitrictech/go-sdk/internal/grpc"
 	"github.com/nitrictech/go-sdk/internal/handlers"
+	"github.com/nitrictech/go-sdk/internal/tracing"
 	"github.com/nitrictech/go-sdk/nitric/errors"
 	"github.com/nitrictech/go-sdk/nitric/errors/codes"
 	"github.com/nitrictech/go-sdk/nitric/workers"
@@ -30,14 +35,26 @@ type scheduleWorker struct {
 	client              v1.SchedulesClient
 	registrationRequest *v1.RegistrationRequest
 	handler             handlers.Handler[Ctx]
+	timeout             time.Duration
+	maxInFlight         int
 }
 type scheduleWorkerOpts struct {
+	Manager             *workers.Manager
 	RegistrationRequest *v1.RegistrationRequest
 	Handler             handlers.Handler[Ctx]
+	Timeout             time.Duration
+	MaxInFlight         int
+}
+
+// Registration implements workers.RegisteredWorker.
+func (i *scheduleWorker) Registration() proto.Message {
+	return i.registrationRequest
 }
 
 // Start runs the Schedule worker, creating a stream to the Nitric server
 func (i *scheduleWorker) Start(ctx context.Context) error {
+	logger := workers.Logger(ctx).With("schedule", i.registrationRequest.ScheduleName)
+
 	initReq := &v1.ClientMessage{
 		Content: &v1.ClientMessage_RegistrationRequest{
 			RegistrationRequest: i.registrationRequest,
@@ -52,10 +69,25 @@ func (i *scheduleWorker) Start(ctx context.Context) error {
 		if msg.GetIntervalRequest() != nil {
 			handlerCtx := NewCtx(msg)
 
-			err := i.handler(handlerCtx)
+			reqCtx, cancel := workers.HandlerContext(ctx, i.timeout)
+			defer cancel()
+			reqCtx, span := tracing.Start(reqCtx, i.registrationRequest.ScheduleName+" schedule", trace.SpanKindConsumer,
+				attribute.String("nitric.schedule.name", i.registrationRequest.ScheduleName),
+				attribute.String("nitric.message.id", msg.Id),
+			)
+			handlerCtx.SetContext(reqCtx)
+			handlerCtx.SetLogger(logger.With("message_id", msg.Id))
+
+			done := workers.Observe(ctx)
+			err := workers.Recover(ctx, func() error {
+				return i.handler(handlerCtx)
+			})
 			if err != nil {
+				handlerCtx.Logger().Error("handler failed", "error", err)
 				handlerCtx.WithError(err)
 			}
+			tracing.End(span, err)
+			done(err)
 
 			return handlerCtx.ToClientMessage(), nil
 		}
@@ -71,11 +103,12 @@ func (i *scheduleWorker) Start(ctx context.Context) error {
 		createStream,
 		initReq,
 		handlerSrvMsg,
+		workers.WithMaxInFlight(i.maxInFlight),
 	)
 }
 
 func newScheduleWorker(opts *scheduleWorkerOpts) *scheduleWorker {
-	conn, err := grpcx.GetConnection()
+	conn, err := opts.Manager.Connection()
 	if err != nil {
 		panic(errors.NewWithCause(
 			codes.Unavailable,
@@ -90,5 +123,7 @@ func newScheduleWorker(opts *scheduleWorkerOpts) *scheduleWorker {
 		client:              client,
 		registrationRequest: opts.RegistrationRequest,
 		handler:             opts.Handler,
+		timeout:             opts.Timeout,
+		maxInFlight:         opts.MaxInFlight,
 	}
 }
@@ -17,9 +17,13 @@ package secrets
 import (
 	"context"
 
+	"google.golang.org/grpc"
+
 	grpcx "github.com/nitrictech/go-sdk/internal/grpc"
+	"github.com/nitrictech/go-sdk/nitric/clients"
 	"github.com/nitrictech/go-sdk/nitric/errors"
 	"github.com/nitrictech/go-sdk/nitric/errors/codes"
+	"github.com/nitrictech/go-sdk/nitric/metrics"
 	v1 "github.com/nitrictech/nitric/core/pkg/proto/secrets/v1"
 )
 
@@ -54,7 +58,10 @@ func (s *SecretClient) Name() string {
 }
 
 // Put - Store a new value in this secret, returning a reference to the new version created
-func (s *SecretClient) Put(ctx context.Context, value []byte) (string, error) {
+func (s *SecretClient) Put(ctx context.Context, value []byte) (_ string, err error) {
+	done := metrics.Start(metrics.Client, s.name, "Secret.Put")
+	defer func() { done(err) }()
+
 	resp, err := s.secretClient.Put(ctx, &v1.SecretPutRequest{
 		Secret: &v1.Secret{
 			Name: s.name,
@@ -76,7 +83,10 @@ func (s *SecretClient) Access(ctx context.Context) (SecretValue, error) {
 }
 
 // AccessVersion - Access a specific version of the secret
-func (s *SecretClient) AccessVersion(ctx context.Context, version string) (SecretValue, error) {
+func (s *SecretClient) AccessVersion(ctx context.Context, version string) (_ SecretValue, err error) {
+	done := metrics.Start(metrics.Client, s.name, "Secret.Access")
+	defer func() { done(err) }()
+
 	r, err := s.secretClient.Access(ctx, &v1.SecretAccessRequest{
 		SecretVersion: &v1.SecretVersion{
 			Secret: &v1.Secret{
@@ -92,8 +102,17 @@ func (s *SecretClient) AccessVersion(ctx context.Context, version string) (Secre
 	return SecretValue(r.GetValue()), nil
 }
 
-func NewSecretClient(name string) (*SecretClient, error) {
-	conn, err := grpcx.GetConnection()
+// ClientOption configures a SecretClient, the options are defined in the clients package.
+type ClientOption = clients.Option
+
+// WithConnection and WithRetryPolicy set the connection the SecretClient uses and the policy its calls are retried with, Put is only retried if the policy sets NonIdempotent.
+var (
+	WithConnection  = clients.WithConnection
+	WithRetryPolicy = clients.WithRetryPolicy
+)
+
+func NewSecretClient(name string, opts ...ClientOption) (*SecretClient, error) {
+	conn, err := grpcx.ClientConnection(opts...)
 	if err != nil {
 		return nil, errors.NewWithCause(
 			codes.Unavailable,
@@ -102,10 +121,14 @@ func NewSecretClient(name string) (*SecretClient, error) {
 		)
 	}
 
+	return newSecretClient(name, conn), nil
+}
+
+func newSecretClient(name string, conn grpc.ClientConnInterface) *SecretClient {
 	sClient := v1.NewSecretManagerClient(conn)
 
 	return &SecretClient{
 		secretClient: sClient,
 		name:         name,
-	}, nil
+	}
 }
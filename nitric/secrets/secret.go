@@ -41,13 +41,26 @@ type secret struct {
 	registerChan <-chan workers.RegisterResult
 }
 
+type SecretOption func(*secret)
+
+// WithManager - Declare the secret with the given manager instead of the default manager
+func WithManager(manager *workers.Manager) SecretOption {
+	return func(s *secret) {
+		s.manager = manager
+	}
+}
+
 // NewSecret - Create a new Secret resource
-func NewSecret(name string) *secret {
+func NewSecret(name string, opts ...SecretOption) *secret {
 	secret := &secret{
 		name:    name,
 		manager: workers.GetDefaultManager(),
 	}
 
+	for _, opt := range opts {
+		opt(secret)
+	}
+
 	secret.registerChan = secret.manager.RegisterResource(&v1.ResourceDeclareRequest{
 		Id: &v1.ResourceIdentifier{
 			Type: v1.ResourceType_Secret,
@@ -86,10 +99,10 @@ func (s *secret) Allow(permission SecretPermission, permissions ...SecretPermiss
 		panic(err)
 	}
 
-	client, err := NewSecretClient(s.name)
+	conn, err := s.manager.Connection()
 	if err != nil {
 		panic(err)
 	}
 
-	return client
+	return newSecretClient(s.name, conn)
 }
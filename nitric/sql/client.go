@@ -17,9 +17,13 @@ package sql
 import (
 	"context"
 
+	"google.golang.org/grpc"
+
 	grpcx "github.com/nitrictech/go-sdk/internal/grpc"
+	"github.com/nitrictech/go-sdk/nitric/clients"
 	"github.com/nitrictech/go-sdk/nitric/errors"
 	"github.com/nitrictech/go-sdk/nitric/errors/codes"
+	"github.com/nitrictech/go-sdk/nitric/metrics"
 
 	v1 "github.com/nitrictech/nitric/core/pkg/proto/sql/v1"
 )
@@ -40,7 +44,10 @@ func (s *SqlClient) Name() string {
 	return s.name
 }
 
-func (s *SqlClient) ConnectionString(ctx context.Context) (string, error) {
+func (s *SqlClient) ConnectionString(ctx context.Context) (_ string, err error) {
+	done := metrics.Start(metrics.Client, s.name, "Sql.ConnectionString")
+	defer func() { done(err) }()
+
 	resp, err := s.sqlClient.ConnectionString(ctx, &v1.SqlConnectionStringRequest{
 		DatabaseName: s.name,
 	})
@@ -51,8 +58,17 @@ func (s *SqlClient) ConnectionString(ctx context.Context) (string, error) {
 	return resp.ConnectionString, nil
 }
 
-func NewSqlClient(name string) (*SqlClient, error) {
-	conn, err := grpcx.GetConnection()
+// ClientOption configures a SqlClient, the options are defined in the clients package.
+type ClientOption = clients.Option
+
+// WithConnection and WithRetryPolicy set the connection the SqlClient uses and the policy its calls are retried with.
+var (
+	WithConnection  = clients.WithConnection
+	WithRetryPolicy = clients.WithRetryPolicy
+)
+
+func NewSqlClient(name string, opts ...ClientOption) (*SqlClient, error) {
+	conn, err := grpcx.ClientConnection(opts...)
 	if err != nil {
 		return nil, errors.NewWithCause(
 			codes.Unavailable,
@@ -61,10 +77,14 @@ func NewSqlClient(name string) (*SqlClient, error) {
 		)
 	}
 
+	return newSqlClient(name, conn), nil
+}
+
+func newSqlClient(name string, conn grpc.ClientConnInterface) *SqlClient {
 	client := v1.NewSqlClient(conn)
 
 	return &SqlClient{
 		name:      name,
 		sqlClient: client,
-	}, nil
+	}
 }
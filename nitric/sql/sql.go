@@ -19,11 +19,16 @@ import (
 	v1 "github.com/nitrictech/nitric/core/pkg/proto/resources/v1"
 )
 
-type sqlDatabaseOption func(*v1.SqlDatabaseResource)
+type sqlDatabase struct {
+	manager  *workers.Manager
+	resource *v1.SqlDatabaseResource
+}
+
+type sqlDatabaseOption func(*sqlDatabase)
 
 func WithMigrationsPath(path string) sqlDatabaseOption {
-	return func(r *v1.SqlDatabaseResource) {
-		r.Migrations = &v1.SqlDatabaseMigrations{
+	return func(db *sqlDatabase) {
+		db.resource.Migrations = &v1.SqlDatabaseMigrations{
 			Migrations: &v1.SqlDatabaseMigrations_MigrationsPath{
 				MigrationsPath: path,
 			},
@@ -31,22 +36,32 @@ func WithMigrationsPath(path string) sqlDatabaseOption {
 	}
 }
 
+// WithManager - Declare the database with the given manager instead of the default manager
+func WithManager(manager *workers.Manager) sqlDatabaseOption {
+	return func(db *sqlDatabase) {
+		db.manager = manager
+	}
+}
+
 // NewSqlDatabase - Create a new Sql Database resource
 func NewSqlDatabase(name string, opts ...sqlDatabaseOption) *SqlClient {
-	resourceConfig := &v1.ResourceDeclareRequest_SqlDatabase{
-		SqlDatabase: &v1.SqlDatabaseResource{},
+	db := &sqlDatabase{
+		manager:  workers.GetDefaultManager(),
+		resource: &v1.SqlDatabaseResource{},
 	}
 
 	for _, opt := range opts {
-		opt(resourceConfig.SqlDatabase)
+		opt(db)
 	}
 
-	registerChan := workers.GetDefaultManager().RegisterResource(&v1.ResourceDeclareRequest{
+	registerChan := db.manager.RegisterResource(&v1.ResourceDeclareRequest{
 		Id: &v1.ResourceIdentifier{
 			Type: v1.ResourceType_SqlDatabase,
 			Name: name,
 		},
-		Config: resourceConfig,
+		Config: &v1.ResourceDeclareRequest_SqlDatabase{
+			SqlDatabase: db.resource,
+		},
 	})
 
 	// Make sure that registerChan is read
@@ -55,10 +70,10 @@ func NewSqlDatabase(name string, opts ...sqlDatabaseOption) *SqlClient {
 		<-registerChan
 	}()
 
-	client, err := NewSqlClient(name)
+	conn, err := db.manager.Connection()
 	if err != nil {
 		panic(err)
 	}
 
-	return client
+	return newSqlClient(name, conn)
 }
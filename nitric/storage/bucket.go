@@ -44,7 +44,7 @@ type Bucket interface {
 	//	func(*storage.Ctx)
 	//	func(*storage.Ctx) error
 	//	Handler[storage.Ctx]
-	On(eventType EventType, notificationPrefixFilter string, handler interface{})
+	On(eventType EventType, notificationPrefixFilter string, handler interface{}, opts ...HandlerOption)
 }
 
 const (
@@ -55,13 +55,26 @@ const (
 
 var BucketEverything []BucketPermission = []BucketPermission{BucketRead, BucketWrite, BucketDelete}
 
+type BucketOption func(*bucket)
+
+// WithManager - Declare the bucket with the given manager instead of the default manager
+func WithManager(manager *workers.Manager) BucketOption {
+	return func(b *bucket) {
+		b.manager = manager
+	}
+}
+
 // NewBucket - Create a new Bucket resource
-func NewBucket(name string) Bucket {
+func NewBucket(name string, opts ...BucketOption) Bucket {
 	bucket := &bucket{
 		name:    name,
 		manager: workers.GetDefaultManager(),
 	}
 
+	for _, opt := range opts {
+		opt(bucket)
+	}
+
 	bucket.registerChan = bucket.manager.RegisterResource(&v1.ResourceDeclareRequest{
 		Id: &v1.ResourceIdentifier{
 			Type: v1.ResourceType_Bucket,
@@ -102,15 +115,17 @@ func (b *bucket) Allow(permission BucketPermission, permissions ...BucketPermiss
 		panic(err)
 	}
 
-	client, err := NewBucketClient(b.name)
+	conn, err := b.manager.Connection()
 	if err != nil {
 		panic(err)
 	}
 
-	return client
+	return newBucketClient(b.name, conn)
 }
 
-func (b *bucket) On(eventType EventType, notificationPrefixFilter string, handler interface{}) {
+func (b *bucket) On(eventType EventType, notificationPrefixFilter string, handler interface{}, opts ...HandlerOption) {
+	options := handlers.NewOptions(opts...)
+
 	var blobEventType storagepb.BlobEventType
 	switch eventType {
 	case WriteNotification:
@@ -130,12 +145,15 @@ func (b *bucket) On(eventType EventType, notificationPrefixFilter string, handle
 		panic(err)
 	}
 
-	opts := &bucketEventWorkerOpts{
+	workerOpts := &bucketEventWorkerOpts{
+		Manager:             b.manager,
 		RegistrationRequest: registrationRequest,
-		Handler:             typedHandler,
+		Handler:             handlers.Chain(typedHandler, options.Middleware...),
+		Timeout:             options.Timeout,
+		MaxInFlight:         options.MaxInFlight,
 	}
 
-	worker := newBucketEventWorker(opts)
+	worker := newBucketEventWorker(workerOpts)
 
 	b.manager.AddWorker("bucketNotification:"+strings.Join([]string{
 		b.name, notificationPrefixFilter, string(eventType),
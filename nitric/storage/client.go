@@ -18,11 +18,16 @@ import (
 	"context"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/durationpb"
 
 	grpcx "github.com/nitrictech/go-sdk/internal/grpc"
+	"github.com/nitrictech/go-sdk/internal/tracing"
+	"github.com/nitrictech/go-sdk/nitric/clients"
 	"github.com/nitrictech/go-sdk/nitric/errors"
 	"github.com/nitrictech/go-sdk/nitric/errors/codes"
+	"github.com/nitrictech/go-sdk/nitric/metrics"
 	v1 "github.com/nitrictech/nitric/core/pkg/proto/storage/v1"
 )
 
@@ -51,7 +56,18 @@ type BucketClient struct {
 	name          string
 }
 
-func (o *BucketClient) Read(ctx context.Context, key string) ([]byte, error) {
+func (o *BucketClient) Read(ctx context.Context, key string) (_ []byte, err error) {
+	ctx, span := tracing.StartClient(
+		ctx, "Bucket.Read",
+		attribute.String("nitric.bucket.name", o.name),
+		attribute.String("nitric.bucket.key", key),
+	)
+	done := metrics.Start(metrics.Client, o.name, "Bucket.Read")
+	defer func() {
+		tracing.End(span, err)
+		done(err)
+	}()
+
 	r, err := o.storageClient.Read(ctx, &v1.StorageReadRequest{
 		BucketName: o.name,
 		Key:        key,
@@ -63,7 +79,18 @@ func (o *BucketClient) Read(ctx context.Context, key string) ([]byte, error) {
 	return r.GetBody(), nil
 }
 
-func (o *BucketClient) Write(ctx context.Context, key string, content []byte) error {
+func (o *BucketClient) Write(ctx context.Context, key string, content []byte) (err error) {
+	ctx, span := tracing.StartClient(
+		ctx, "Bucket.Write",
+		attribute.String("nitric.bucket.name", o.name),
+		attribute.String("nitric.bucket.key", key),
+	)
+	done := metrics.Start(metrics.Client, o.name, "Bucket.Write")
+	defer func() {
+		tracing.End(span, err)
+		done(err)
+	}()
+
 	if _, err := o.storageClient.Write(ctx, &v1.StorageWriteRequest{
 		BucketName: o.name,
 		Key:        key,
@@ -75,7 +102,18 @@ func (o *BucketClient) Write(ctx context.Context, key string, content []byte) er
 	return nil
 }
 
-func (o *BucketClient) Delete(ctx context.Context, key string) error {
+func (o *BucketClient) Delete(ctx context.Context, key string) (err error) {
+	ctx, span := tracing.StartClient(
+		ctx, "Bucket.Delete",
+		attribute.String("nitric.bucket.name", o.name),
+		attribute.String("nitric.bucket.key", key),
+	)
+	done := metrics.Start(metrics.Client, o.name, "Bucket.Delete")
+	defer func() {
+		tracing.End(span, err)
+		done(err)
+	}()
+
 	if _, err := o.storageClient.Delete(ctx, &v1.StorageDeleteRequest{
 		BucketName: o.name,
 		Key:        key,
@@ -86,7 +124,17 @@ func (o *BucketClient) Delete(ctx context.Context, key string) error {
 	return nil
 }
 
-func (b *BucketClient) ListFiles(ctx context.Context) ([]string, error) {
+func (b *BucketClient) ListFiles(ctx context.Context) (_ []string, err error) {
+	ctx, span := tracing.StartClient(
+		ctx, "Bucket.ListFiles",
+		attribute.String("nitric.bucket.name", b.name),
+	)
+	done := metrics.Start(metrics.Client, b.name, "Bucket.ListFiles")
+	defer func() {
+		tracing.End(span, err)
+		done(err)
+	}()
+
 	resp, err := b.storageClient.ListBlobs(ctx, &v1.StorageListBlobsRequest{
 		BucketName: b.name,
 	})
@@ -148,7 +196,18 @@ func (o *BucketClient) DownloadUrl(ctx context.Context, key string, opts ...Pres
 	return o.signUrl(ctx, key, optsWithDefaults)
 }
 
-func (o *BucketClient) signUrl(ctx context.Context, key string, opts *presignUrlOptions) (string, error) {
+func (o *BucketClient) signUrl(ctx context.Context, key string, opts *presignUrlOptions) (_ string, err error) {
+	ctx, span := tracing.StartClient(
+		ctx, "Bucket.PreSignUrl",
+		attribute.String("nitric.bucket.name", o.name),
+		attribute.String("nitric.bucket.key", key),
+	)
+	done := metrics.Start(metrics.Client, o.name, "Bucket.PreSignUrl")
+	defer func() {
+		tracing.End(span, err)
+		done(err)
+	}()
+
 	op := v1.StoragePreSignUrlRequest_READ
 
 	if opts.mode == ModeWrite {
@@ -172,8 +231,17 @@ func (b *BucketClient) Name() string {
 	return b.name
 }
 
-func NewBucketClient(name string) (*BucketClient, error) {
-	conn, err := grpcx.GetConnection()
+// ClientOption configures a BucketClient, the options are defined in the clients package.
+type ClientOption = clients.Option
+
+// WithConnection and WithRetryPolicy set the connection the BucketClient uses and the policy its calls are retried with.
+var (
+	WithConnection  = clients.WithConnection
+	WithRetryPolicy = clients.WithRetryPolicy
+)
+
+func NewBucketClient(name string, opts ...ClientOption) (*BucketClient, error) {
+	conn, err := grpcx.ClientConnection(opts...)
 	if err != nil {
 		return nil, errors.NewWithCause(
 			codes.Unavailable,
@@ -182,10 +250,14 @@ func NewBucketClient(name string) (*BucketClient, error) {
 		)
 	}
 
+	return newBucketClient(name, conn), nil
+}
+
+func newBucketClient(name string, conn grpc.ClientConnInterface) *BucketClient {
 	storageClient := v1.NewStorageClient(conn)
 
 	return &BucketClient{
 		name:          name,
 		storageClient: storageClient,
-	}, nil
+	}
 }
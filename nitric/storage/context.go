@@ -14,9 +14,14 @@
 
 package storage
 
-import storagepb "github.com/nitrictech/nitric/core/pkg/proto/storage/v1"
+import (
+	"github.com/nitrictech/go-sdk/internal/handlers"
+	storagepb "github.com/nitrictech/nitric/core/pkg/proto/storage/v1"
+)
 
 type Ctx struct {
+	handlers.RequestContext
+
 	id       string
 	Request  Request
 	Response *Response
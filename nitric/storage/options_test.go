@@ -0,0 +1,42 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Chain", func() {
+	It("should call composed middleware in the order provided, outermost first", func() {
+		calls := []string{}
+		record := func(name string) Middleware {
+			return func(next Handler) Handler {
+				return func(ctx *Ctx) error {
+					calls = append(calls, name)
+					return next(ctx)
+				}
+			}
+		}
+
+		handler := Chain(func(ctx *Ctx) error {
+			calls = append(calls, "handler")
+			return nil
+		}, Compose(record("first"), record("second")), record("third"))
+
+		Expect(handler(&Ctx{})).To(Succeed())
+		Expect(calls).To(Equal([]string{"first", "second", "third", "handler"}))
+	})
+})
@@ -17,9 +17,14 @@ package storage
 import (
 	"context"
 	errorsstd "errors"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
 
-	grpcx "github.com/nitrictech/go-sdk/internal/grpc"
 	"github.com/nitrictech/go-sdk/internal/handlers"
+	"github.com/nitrictech/go-sdk/internal/tracing"
 	"github.com/nitrictech/go-sdk/nitric/errors"
 	"github.com/nitrictech/go-sdk/nitric/errors/codes"
 	"github.com/nitrictech/go-sdk/nitric/workers"
@@ -30,14 +35,26 @@ type bucketEventWorker struct {
 	client              v1.StorageListenerClient
 	registrationRequest *v1.RegistrationRequest
 	handler             handlers.Handler[Ctx]
+	timeout             time.Duration
+	maxInFlight         int
 }
 type bucketEventWorkerOpts struct {
+	Manager             *workers.Manager
 	RegistrationRequest *v1.RegistrationRequest
 	Handler             handlers.Handler[Ctx]
+	Timeout             time.Duration
+	MaxInFlight         int
+}
+
+// Registration implements workers.RegisteredWorker.
+func (b *bucketEventWorker) Registration() proto.Message {
+	return b.registrationRequest
 }
 
 // Start runs the BucketEvent worker, creating a stream to the Nitric server
 func (b *bucketEventWorker) Start(ctx context.Context) error {
+	logger := workers.Logger(ctx).With("bucket", b.registrationRequest.BucketName)
+
 	initReq := &v1.ClientMessage{
 		Content: &v1.ClientMessage_RegistrationRequest{
 			RegistrationRequest: b.registrationRequest,
@@ -52,10 +69,27 @@ func (b *bucketEventWorker) Start(ctx context.Context) error {
 		if msg.GetBlobEventRequest() != nil {
 			handlerCtx := NewCtx(msg)
 
-			err := b.handler(handlerCtx)
+			reqCtx, cancel := workers.HandlerContext(ctx, b.timeout)
+			defer cancel()
+			reqCtx, span := tracing.Start(reqCtx, b.registrationRequest.BucketName+" "+string(handlerCtx.Request.NotificationType()), trace.SpanKindConsumer,
+				attribute.String("nitric.bucket.name", b.registrationRequest.BucketName),
+				attribute.String("nitric.bucket.key", handlerCtx.Request.Key()),
+				attribute.String("nitric.bucket.event_type", string(handlerCtx.Request.NotificationType())),
+				attribute.String("nitric.message.id", msg.Id),
+			)
+			handlerCtx.SetContext(reqCtx)
+			handlerCtx.SetLogger(logger.With("message_id", msg.Id))
+
+			done := workers.Observe(ctx)
+			err := workers.Recover(ctx, func() error {
+				return b.handler(handlerCtx)
+			})
 			if err != nil {
+				handlerCtx.Logger().Error("handler failed", "error", err)
 				handlerCtx.WithError(err)
 			}
+			tracing.End(span, err)
+			done(err)
 
 			return handlerCtx.ToClientMessage(), nil
 		}
@@ -67,11 +101,11 @@ func (b *bucketEventWorker) Start(ctx context.Context) error {
 		)
 	}
 
-	return workers.HandleStream(ctx, createStream, initReq, handlerSrvMsg)
+	return workers.HandleStream(ctx, createStream, initReq, handlerSrvMsg, workers.WithMaxInFlight(b.maxInFlight))
 }
 
 func newBucketEventWorker(opts *bucketEventWorkerOpts) *bucketEventWorker {
-	conn, err := grpcx.GetConnection()
+	conn, err := opts.Manager.Connection()
 	if err != nil {
 		panic(errors.NewWithCause(
 			codes.Unavailable,
@@ -86,5 +120,7 @@ func newBucketEventWorker(opts *bucketEventWorkerOpts) *bucketEventWorker {
 		client:              client,
 		registrationRequest: opts.RegistrationRequest,
 		handler:             opts.Handler,
+		timeout:             opts.Timeout,
+		maxInFlight:         opts.MaxInFlight,
 	}
 }
@@ -18,11 +18,16 @@ import (
 	"context"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/durationpb"
 
 	grpcx "github.com/nitrictech/go-sdk/internal/grpc"
+	"github.com/nitrictech/go-sdk/internal/tracing"
+	"github.com/nitrictech/go-sdk/nitric/clients"
 	"github.com/nitrictech/go-sdk/nitric/errors"
 	"github.com/nitrictech/go-sdk/nitric/errors/codes"
+	"github.com/nitrictech/go-sdk/nitric/metrics"
 	v1 "github.com/nitrictech/nitric/core/pkg/proto/topics/v1"
 	"github.com/nitrictech/protoutils"
 )
@@ -54,8 +59,20 @@ func WithDelay(duration time.Duration) func(*v1.TopicPublishRequest) {
 	}
 }
 
-func (s *TopicClient) Publish(ctx context.Context, message map[string]interface{}, opts ...PublishOption) error {
-	payloadStruct, err := protoutils.NewStruct(message)
+func (s *TopicClient) Publish(ctx context.Context, message map[string]interface{}, opts ...PublishOption) (err error) {
+	ctx, span := tracing.StartClient(
+		ctx, "Topic.Publish",
+		attribute.String("messaging.system", "nitric"),
+		attribute.String("messaging.destination.name", s.name),
+		attribute.String("messaging.operation", "publish"),
+	)
+	done := metrics.Start(metrics.Client, s.name, "Topic.Publish")
+	defer func() {
+		tracing.End(span, err)
+		done(err)
+	}()
+
+	payloadStruct, err := protoutils.NewStruct(tracing.InjectMessage(ctx, message))
 	if err != nil {
 		return errors.NewWithCause(codes.InvalidArgument, "Topic.Publish", err)
 	}
@@ -82,8 +99,17 @@ func (s *TopicClient) Publish(ctx context.Context, message map[string]interface{
 	return nil
 }
 
-func NewTopicClient(name string) (*TopicClient, error) {
-	conn, err := grpcx.GetConnection()
+// ClientOption configures a TopicClient, the options are defined in the clients package.
+type ClientOption = clients.Option
+
+// WithConnection and WithRetryPolicy set the connection the TopicClient uses and the policy its calls are retried with, Publish is only retried if the policy sets NonIdempotent.
+var (
+	WithConnection  = clients.WithConnection
+	WithRetryPolicy = clients.WithRetryPolicy
+)
+
+func NewTopicClient(name string, opts ...ClientOption) (*TopicClient, error) {
+	conn, err := grpcx.ClientConnection(opts...)
 	if err != nil {
 		return nil, errors.NewWithCause(
 			codes.Unavailable,
@@ -92,10 +118,14 @@ func NewTopicClient(name string) (*TopicClient, error) {
 		)
 	}
 
+	return newTopicClient(name, conn), nil
+}
+
+func newTopicClient(name string, conn grpc.ClientConnInterface) *TopicClient {
 	topicClient := v1.NewTopicsClient(conn)
 
 	return &TopicClient{
 		name:        name,
 		topicClient: topicClient,
-	}, nil
+	}
 }
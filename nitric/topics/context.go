@@ -14,9 +14,14 @@
 
 package topics
 
-import topicspb "github.com/nitrictech/nitric/core/pkg/proto/topics/v1"
+import (
+	"github.com/nitrictech/go-sdk/internal/handlers"
+	topicspb "github.com/nitrictech/nitric/core/pkg/proto/topics/v1"
+)
 
 type Ctx struct {
+	handlers.RequestContext
+
 	id       string
 	Request  Request
 	Response *Response
@@ -43,7 +43,7 @@ type SubscribableTopic interface {
 	//	func(*topics.Ctx)
 	//	func(*topics.Ctx) error
 	//	Handler[topics.Ctx]
-	Subscribe(handler interface{})
+	Subscribe(handler interface{}, opts ...HandlerOption)
 }
 
 type subscribableTopic struct {
@@ -52,13 +52,26 @@ type subscribableTopic struct {
 	registerChan <-chan workers.RegisterResult
 }
 
+type TopicOption func(*subscribableTopic)
+
+// WithManager - Declare the topic with the given manager instead of the default manager
+func WithManager(manager *workers.Manager) TopicOption {
+	return func(t *subscribableTopic) {
+		t.manager = manager
+	}
+}
+
 // NewTopic creates a new Topic with the give name.
-func NewTopic(name string) SubscribableTopic {
+func NewTopic(name string, opts ...TopicOption) SubscribableTopic {
 	topic := &subscribableTopic{
 		name:    name,
 		manager: workers.GetDefaultManager(),
 	}
 
+	for _, opt := range opts {
+		opt(topic)
+	}
+
 	topic.registerChan = topic.manager.RegisterResource(&v1.ResourceDeclareRequest{
 		Id: &v1.ResourceIdentifier{
 			Type: v1.ResourceType_Topic,
@@ -95,15 +108,17 @@ func (t *subscribableTopic) Allow(permission TopicPermission, permissions ...Top
 		panic(err)
 	}
 
-	client, err := NewTopicClient(t.name)
+	conn, err := t.manager.Connection()
 	if err != nil {
 		panic(err)
 	}
 
-	return client
+	return newTopicClient(t.name, conn)
 }
 
-func (t *subscribableTopic) Subscribe(handler interface{}) {
+func (t *subscribableTopic) Subscribe(handler interface{}, opts ...HandlerOption) {
+	options := handlers.NewOptions(opts...)
+
 	registrationRequest := &topicspb.RegistrationRequest{
 		TopicName: t.name,
 	}
@@ -113,11 +128,14 @@ func (t *subscribableTopic) Subscribe(handler interface{}) {
 		panic(err)
 	}
 
-	opts := &subscriptionWorkerOpts{
+	workerOpts := &subscriptionWorkerOpts{
+		Manager:             t.manager,
 		RegistrationRequest: registrationRequest,
-		Handler:             typedHandler,
+		Handler:             handlers.Chain(typedHandler, options.Middleware...),
+		Timeout:             options.Timeout,
+		MaxInFlight:         options.MaxInFlight,
 	}
 
-	worker := newSubscriptionWorker(opts)
+	worker := newSubscriptionWorker(workerOpts)
 	t.manager.AddWorker("SubscriptionWorker:"+t.name, worker)
 }
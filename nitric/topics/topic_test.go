@@ -0,0 +1,93 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topics
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+
+	"github.com/nitrictech/go-sdk/nitric/workers"
+)
+
+// recordingConn records the methods invoked on it, in place of a connection to the Nitric server.
+type recordingConn struct {
+	grpc.ClientConnInterface
+	methods []string
+}
+
+func (r *recordingConn) Invoke(ctx context.Context, method string, args any, reply any, opts ...grpc.CallOption) error {
+	r.methods = append(r.methods, method)
+	return nil
+}
+
+var _ = Describe("NewTopic", func() {
+	When("declared with a manager", func() {
+		var (
+			manager *workers.Manager
+			conn    *recordingConn
+			topic   SubscribableTopic
+		)
+
+		BeforeEach(func() {
+			conn = &recordingConn{}
+
+			manager = workers.New()
+			manager.SetOffline(true)
+			manager.SetConnection(conn)
+
+			topic = NewTopic("isolated", WithManager(manager))
+		})
+
+		It("should declare the topic with the manager", func() {
+			Expect(manager.Manifest().Resources()).To(HaveLen(1))
+			Expect(manager.Manifest().Resources()[0].GetId().GetName()).To(Equal("isolated"))
+
+			for _, resource := range workers.GetDefaultManager().Manifest().Resources() {
+				Expect(resource.GetId().GetName()).ToNot(Equal("isolated"))
+			}
+		})
+
+		It("should add subscriptions to the manager", func() {
+			topic.Subscribe(func() {})
+
+			Expect(manager.Manifest().Registrations()).To(HaveKey("SubscriptionWorker:isolated"))
+			Expect(workers.GetDefaultManager().Manifest().Registrations()).ToNot(HaveKey("SubscriptionWorker:isolated"))
+		})
+
+		It("should create clients using the manager's connection", func() {
+			client := topic.Allow(TopicPublish)
+
+			Expect(client.Publish(context.Background(), map[string]interface{}{})).To(Succeed())
+			Expect(conn.methods).To(Equal([]string{"/nitric.proto.topics.v1.Topics/Publish"}))
+		})
+
+		It("should run the manager's interceptors around client calls", func() {
+			intercepted := []string{}
+			manager.AddUnaryInterceptors(func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+				intercepted = append(intercepted, method)
+				return invoker(ctx, method, req, reply, cc, opts...)
+			})
+
+			client := topic.Allow(TopicPublish)
+
+			Expect(client.Publish(context.Background(), map[string]interface{}{})).To(Succeed())
+			Expect(intercepted).To(Equal([]string{"/nitric.proto.topics.v1.Topics/Publish"}))
+			Expect(conn.methods).To(Equal(intercepted))
+		})
+	})
+})
@@ -16,11 +16,16 @@ package topics
 
 import (
 	"context"
+	"time"
 
 	errorsstd "errors"
 
-	grpcx "github.com/nitrictech/go-sdk/internal/grpc"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+
 	"github.com/nitrictech/go-sdk/internal/handlers"
+	"github.com/nitrictech/go-sdk/internal/tracing"
 	"github.com/nitrictech/go-sdk/nitric/errors"
 	"github.com/nitrictech/go-sdk/nitric/errors/codes"
 	"github.com/nitrictech/go-sdk/nitric/workers"
@@ -31,14 +36,26 @@ type subscriptionWorker struct {
 	client              v1.SubscriberClient
 	registrationRequest *v1.RegistrationRequest
 	handler             handlers.Handler[Ctx]
+	timeout             time.Duration
+	maxInFlight         int
 }
 type subscriptionWorkerOpts struct {
+	Manager             *workers.Manager
 	RegistrationRequest *v1.RegistrationRequest
 	Handler             handlers.Handler[Ctx]
+	Timeout             time.Duration
+	MaxInFlight         int
+}
+
+// Registration implements workers.RegisteredWorker.
+func (s *subscriptionWorker) Registration() proto.Message {
+	return s.registrationRequest
 }
 
 // Start implements Worker.
 func (s *subscriptionWorker) Start(ctx context.Context) error {
+	logger := workers.Logger(ctx).With("topic", s.registrationRequest.TopicName)
+
 	initReq := &v1.ClientMessage{
 		Content: &v1.ClientMessage_RegistrationRequest{
 			RegistrationRequest: s.registrationRequest,
@@ -53,10 +70,28 @@ func (s *subscriptionWorker) Start(ctx context.Context) error {
 		if msg.GetMessageRequest() != nil {
 			handlerCtx := NewCtx(msg)
 
-			err := s.handler(handlerCtx)
+			reqCtx, cancel := workers.HandlerContext(ctx, s.timeout)
+			defer cancel()
+			reqCtx = tracing.ExtractMessage(reqCtx, handlerCtx.Request.Message())
+			reqCtx, span := tracing.Start(reqCtx, s.registrationRequest.TopicName+" process", trace.SpanKindConsumer,
+				attribute.String("messaging.system", "nitric"),
+				attribute.String("messaging.destination.name", s.registrationRequest.TopicName),
+				attribute.String("messaging.operation", "process"),
+				attribute.String("messaging.message.id", msg.Id),
+			)
+			handlerCtx.SetContext(reqCtx)
+			handlerCtx.SetLogger(logger.With("message_id", msg.Id))
+
+			done := workers.Observe(ctx)
+			err := workers.Recover(ctx, func() error {
+				return s.handler(handlerCtx)
+			})
 			if err != nil {
+				handlerCtx.Logger().Error("handler failed", "error", err)
 				handlerCtx.WithError(err)
 			}
+			tracing.End(span, err)
+			done(err)
 
 			return handlerCtx.ToClientMessage(), nil
 		}
@@ -68,11 +103,11 @@ func (s *subscriptionWorker) Start(ctx context.Context) error {
 		)
 	}
 
-	return workers.HandleStream(ctx, createStream, initReq, handleSrvMsg)
+	return workers.HandleStream(ctx, createStream, initReq, handleSrvMsg, workers.WithMaxInFlight(s.maxInFlight))
 }
 
 func newSubscriptionWorker(opts *subscriptionWorkerOpts) *subscriptionWorker {
-	conn, err := grpcx.GetConnection()
+	conn, err := opts.Manager.Connection()
 	if err != nil {
 		panic(errors.NewWithCause(
 			codes.Unavailable,
@@ -87,5 +122,7 @@ func newSubscriptionWorker(opts *subscriptionWorkerOpts) *subscriptionWorker {
 		client:              client,
 		registrationRequest: opts.RegistrationRequest,
 		handler:             opts.Handler,
+		timeout:             opts.Timeout,
+		maxInFlight:         opts.MaxInFlight,
 	}
 }
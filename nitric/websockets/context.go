@@ -14,9 +14,14 @@
 
 package websockets
 
-import websocketspb "github.com/nitrictech/nitric/core/pkg/proto/websockets/v1"
+import (
+	"github.com/nitrictech/go-sdk/internal/handlers"
+	websocketspb "github.com/nitrictech/nitric/core/pkg/proto/websockets/v1"
+)
 
 type Ctx struct {
+	handlers.RequestContext
+
 	id       string
 	Request  Request
 	Response *Response
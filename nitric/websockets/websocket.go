@@ -18,8 +18,8 @@ import (
 	"context"
 	"strings"
 
-	grpcx "github.com/nitrictech/go-sdk/internal/grpc"
 	"github.com/nitrictech/go-sdk/internal/handlers"
+	"github.com/nitrictech/go-sdk/nitric/metrics"
 	"github.com/nitrictech/go-sdk/nitric/workers"
 	resourcesv1 "github.com/nitrictech/nitric/core/pkg/proto/resources/v1"
 	websocketsv1 "github.com/nitrictech/nitric/core/pkg/proto/websockets/v1"
@@ -37,7 +37,7 @@ type Websocket interface {
 	//	func(*websocket.Ctx)
 	//	func(*websocket.Ctx) error
 	//	Handler[websocket.Ctx]
-	On(eventType EventType, handler interface{})
+	On(eventType EventType, handler interface{}, opts ...HandlerOption)
 	// Send a message to a specific connection
 	Send(ctx context.Context, connectionId string, message []byte) error
 	// Close a specific connection
@@ -52,11 +52,27 @@ type websocket struct {
 	client  websocketsv1.WebsocketClient
 }
 
+type WebsocketOption func(*websocket)
+
+// WithManager - Declare the websocket with the given manager instead of the default manager
+func WithManager(manager *workers.Manager) WebsocketOption {
+	return func(w *websocket) {
+		w.manager = manager
+	}
+}
+
 // NewWebsocket - Create a new Websocket API resource
-func NewWebsocket(name string) Websocket {
-	manager := workers.GetDefaultManager()
+func NewWebsocket(name string, opts ...WebsocketOption) Websocket {
+	ws := &websocket{
+		name:    name,
+		manager: workers.GetDefaultManager(),
+	}
 
-	registerResult := <-manager.RegisterResource(&resourcesv1.ResourceDeclareRequest{
+	for _, opt := range opts {
+		opt(ws)
+	}
+
+	registerResult := <-ws.manager.RegisterResource(&resourcesv1.ResourceDeclareRequest{
 		Id: &resourcesv1.ResourceIdentifier{
 			Type: resourcesv1.ResourceType_Websocket,
 			Name: name,
@@ -68,30 +84,28 @@ func NewWebsocket(name string) Websocket {
 
 	actions := []resourcesv1.Action{resourcesv1.Action_WebsocketManage}
 
-	err := manager.RegisterPolicy(registerResult.Identifier, actions...)
+	err := ws.manager.RegisterPolicy(registerResult.Identifier, actions...)
 	if err != nil {
 		panic(err)
 	}
 
-	conn, err := grpcx.GetConnection()
+	conn, err := ws.manager.Connection()
 	if err != nil {
 		panic(err)
 	}
 
-	wClient := websocketsv1.NewWebsocketClient(conn)
+	ws.client = websocketsv1.NewWebsocketClient(conn)
 
-	return &websocket{
-		manager: manager,
-		client:  wClient,
-		name:    name,
-	}
+	return ws
 }
 
 func (w *websocket) Name() string {
 	return w.name
 }
 
-func (w *websocket) On(eventType EventType, handler interface{}) {
+func (w *websocket) On(eventType EventType, handler interface{}, opts ...HandlerOption) {
+	options := handlers.NewOptions(opts...)
+
 	var _eventType websocketsv1.WebsocketEventType
 	switch eventType {
 	case EventType_Disconnect:
@@ -112,20 +126,26 @@ func (w *websocket) On(eventType EventType, handler interface{}) {
 		panic(err)
 	}
 
-	opts := &websocketWorkerOpts{
+	workerOpts := &websocketWorkerOpts{
+		Manager:             w.manager,
 		RegistrationRequest: registrationRequest,
-		Handler:             typedHandler,
+		Handler:             handlers.Chain(typedHandler, options.Middleware...),
+		Timeout:             options.Timeout,
+		MaxInFlight:         options.MaxInFlight,
 	}
 
-	worker := newWebsocketWorker(opts)
+	worker := newWebsocketWorker(workerOpts)
 	w.manager.AddWorker("WebsocketWorker:"+strings.Join([]string{
 		w.name,
 		string(eventType),
 	}, "-"), worker)
 }
 
-func (w *websocket) Send(ctx context.Context, connectionId string, message []byte) error {
-	_, err := w.client.SendMessage(ctx, &websocketsv1.WebsocketSendRequest{
+func (w *websocket) Send(ctx context.Context, connectionId string, message []byte) (err error) {
+	done := metrics.Start(metrics.Client, w.name, "Websocket.Send")
+	defer func() { done(err) }()
+
+	_, err = w.client.SendMessage(ctx, &websocketsv1.WebsocketSendRequest{
 		SocketName:   w.name,
 		ConnectionId: connectionId,
 		Data:         message,
@@ -134,8 +154,11 @@ func (w *websocket) Send(ctx context.Context, connectionId string, message []byt
 	return err
 }
 
-func (w *websocket) Close(ctx context.Context, connectionId string) error {
-	_, err := w.client.CloseConnection(ctx, &websocketsv1.WebsocketCloseConnectionRequest{
+func (w *websocket) Close(ctx context.Context, connectionId string) (err error) {
+	done := metrics.Start(metrics.Client, w.name, "Websocket.Close")
+	defer func() { done(err) }()
+
+	_, err = w.client.CloseConnection(ctx, &websocketsv1.WebsocketCloseConnectionRequest{
 		SocketName:   w.name,
 		ConnectionId: connectionId,
 	})
@@ -0,0 +1,102 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package websockets
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+
+	"github.com/nitrictech/go-sdk/nitric/metrics"
+	websocketsv1 "github.com/nitrictech/nitric/core/pkg/proto/websockets/v1"
+)
+
+// fakeClient returns err from each call, in place of a connection to the Nitric server.
+type fakeClient struct {
+	websocketsv1.WebsocketClient
+	err error
+}
+
+func (f *fakeClient) SendMessage(ctx context.Context, in *websocketsv1.WebsocketSendRequest, opts ...grpc.CallOption) (*websocketsv1.WebsocketSendResponse, error) {
+	return &websocketsv1.WebsocketSendResponse{}, f.err
+}
+
+func (f *fakeClient) CloseConnection(ctx context.Context, in *websocketsv1.WebsocketCloseConnectionRequest, opts ...grpc.CallOption) (*websocketsv1.WebsocketCloseConnectionResponse, error) {
+	return &websocketsv1.WebsocketCloseConnectionResponse{}, f.err
+}
+
+// recordedCall is an operation ended on the fakeRecorder.
+type recordedCall struct {
+	op  metrics.Operation
+	err error
+}
+
+type fakeRecorder struct {
+	started []metrics.Operation
+	ended   []recordedCall
+}
+
+func (f *fakeRecorder) Start(op metrics.Operation) {
+	f.started = append(f.started, op)
+}
+
+func (f *fakeRecorder) End(op metrics.Operation, duration time.Duration, err error) {
+	f.ended = append(f.ended, recordedCall{op: op, err: err})
+}
+
+var _ = Describe("Websocket", func() {
+	var (
+		client   *fakeClient
+		ws       *websocket
+		recorder *fakeRecorder
+	)
+
+	BeforeEach(func() {
+		client = &fakeClient{}
+		ws = &websocket{name: "chat", client: client}
+		recorder = &fakeRecorder{}
+		metrics.SetRecorder(recorder)
+	})
+
+	AfterEach(func() {
+		metrics.SetRecorder(nil)
+	})
+
+	Describe("Send()", func() {
+		It("should record the call", func() {
+			Expect(ws.Send(context.Background(), "connection", []byte("hello"))).To(Succeed())
+
+			op := metrics.Operation{Kind: metrics.Client, Name: "chat", Method: "Websocket.Send"}
+			Expect(recorder.started).To(Equal([]metrics.Operation{op}))
+			Expect(recorder.ended).To(Equal([]recordedCall{{op: op}}))
+		})
+	})
+
+	Describe("Close()", func() {
+		It("should record the call and its error", func() {
+			client.err = errors.New("connection not found")
+
+			Expect(ws.Close(context.Background(), "connection")).To(MatchError("connection not found"))
+
+			op := metrics.Operation{Kind: metrics.Client, Name: "chat", Method: "Websocket.Close"}
+			Expect(recorder.started).To(Equal([]metrics.Operation{op}))
+			Expect(recorder.ended).To(Equal([]recordedCall{{op: op, err: client.err}}))
+		})
+	})
+})
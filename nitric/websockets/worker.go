@@ -17,9 +17,14 @@ package websockets
 import (
 	"context"
 	errorsstd "errors"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
 
-	grpcx "github.com/nitrictech/go-sdk/internal/grpc"
 	"github.com/nitrictech/go-sdk/internal/handlers"
+	"github.com/nitrictech/go-sdk/internal/tracing"
 	"github.com/nitrictech/go-sdk/nitric/errors"
 	"github.com/nitrictech/go-sdk/nitric/errors/codes"
 	"github.com/nitrictech/go-sdk/nitric/workers"
@@ -30,14 +35,26 @@ type websocketWorker struct {
 	client              v1.WebsocketHandlerClient
 	registrationRequest *v1.RegistrationRequest
 	handler             handlers.Handler[Ctx]
+	timeout             time.Duration
+	maxInFlight         int
 }
 type websocketWorkerOpts struct {
+	Manager             *workers.Manager
 	RegistrationRequest *v1.RegistrationRequest
 	Handler             handlers.Handler[Ctx]
+	Timeout             time.Duration
+	MaxInFlight         int
+}
+
+// Registration implements workers.RegisteredWorker.
+func (w *websocketWorker) Registration() proto.Message {
+	return w.registrationRequest
 }
 
 // Start implements Worker.
 func (w *websocketWorker) Start(ctx context.Context) error {
+	logger := workers.Logger(ctx).With("websocket", w.registrationRequest.SocketName)
+
 	initReq := &v1.ClientMessage{
 		Content: &v1.ClientMessage_RegistrationRequest{
 			RegistrationRequest: w.registrationRequest,
@@ -52,10 +69,27 @@ func (w *websocketWorker) Start(ctx context.Context) error {
 		if msg.GetWebsocketEventRequest() != nil {
 			handlerCtx := NewCtx(msg)
 
-			err := w.handler(handlerCtx)
+			reqCtx, cancel := workers.HandlerContext(ctx, w.timeout)
+			defer cancel()
+			reqCtx, span := tracing.Start(reqCtx, w.registrationRequest.SocketName+" "+string(handlerCtx.Request.EventType()), trace.SpanKindServer,
+				attribute.String("nitric.websocket.name", w.registrationRequest.SocketName),
+				attribute.String("nitric.websocket.event_type", string(handlerCtx.Request.EventType())),
+				attribute.String("nitric.websocket.connection_id", handlerCtx.Request.ConnectionID()),
+				attribute.String("nitric.message.id", msg.Id),
+			)
+			handlerCtx.SetContext(reqCtx)
+			handlerCtx.SetLogger(logger.With("message_id", msg.Id))
+
+			done := workers.Observe(ctx)
+			err := workers.Recover(ctx, func() error {
+				return w.handler(handlerCtx)
+			})
 			if err != nil {
+				handlerCtx.Logger().Error("handler failed", "error", err)
 				handlerCtx.WithError(err)
 			}
+			tracing.End(span, err)
+			done(err)
 			return handlerCtx.ToClientMessage(), nil
 		}
 
@@ -66,11 +100,11 @@ func (w *websocketWorker) Start(ctx context.Context) error {
 		)
 	}
 
-	return workers.HandleStream(ctx, createStream, initReq, handlerSrvMsg)
+	return workers.HandleStream(ctx, createStream, initReq, handlerSrvMsg, workers.WithMaxInFlight(w.maxInFlight))
 }
 
 func newWebsocketWorker(opts *websocketWorkerOpts) *websocketWorker {
-	conn, err := grpcx.GetConnection()
+	conn, err := opts.Manager.Connection()
 	if err != nil {
 		panic(errors.NewWithCause(
 			codes.Unavailable,
@@ -85,5 +119,7 @@ func newWebsocketWorker(opts *websocketWorkerOpts) *websocketWorker {
 		client:              client,
 		registrationRequest: opts.RegistrationRequest,
 		handler:             opts.Handler,
+		timeout:             opts.Timeout,
+		maxInFlight:         opts.MaxInFlight,
 	}
 }
@@ -0,0 +1,74 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workers
+
+import "context"
+
+type workerContextKey struct{}
+
+type workerContext struct {
+	manager *Manager
+	name    string
+	// shutdown is cancelled when the manager starts shutting down.
+	// The worker and handler contexts are only cancelled once the shutdown timeout elapses, so in-flight messages can still be handled.
+	shutdown context.Context
+	// ready is called when the worker's registration is acknowledged
+	ready func()
+}
+
+// withWorker adds the manager, name and lifecycle signals of the worker to the context passed to the worker.
+func withWorker(ctx context.Context, wc *workerContext) context.Context {
+	return context.WithValue(ctx, workerContextKey{}, wc)
+}
+
+func workerFromContext(ctx context.Context) (*Manager, string) {
+	wc, ok := ctx.Value(workerContextKey{}).(*workerContext)
+	if !ok {
+		return nil, ""
+	}
+
+	return wc.manager, wc.name
+}
+
+// shutdownSignal returns a channel that is closed when the worker should stop accepting new messages.
+// Workers that aren't run by a manager stop when their context is cancelled.
+func shutdownSignal(ctx context.Context) <-chan struct{} {
+	wc, ok := ctx.Value(workerContextKey{}).(*workerContext)
+	if !ok || wc.shutdown == nil {
+		return ctx.Done()
+	}
+
+	return wc.shutdown.Done()
+}
+
+// ShutdownSignal returns a channel that is closed when the manager running the worker starts shutting down.
+// Handlers can use it to stop long-running work early, their context stays usable until the shutdown timeout elapses.
+// The channel is never closed if ctx doesn't belong to a worker run by a manager.
+func ShutdownSignal(ctx context.Context) <-chan struct{} {
+	wc, ok := ctx.Value(workerContextKey{}).(*workerContext)
+	if !ok || wc.shutdown == nil {
+		return nil
+	}
+
+	return wc.shutdown.Done()
+}
+
+// markReady notifies the manager that the worker's registration has been acknowledged.
+func markReady(ctx context.Context) {
+	wc, ok := ctx.Value(workerContextKey{}).(*workerContext)
+	if ok && wc.ready != nil {
+		wc.ready()
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workers
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultShutdownTimeout is the shutdown timeout used by managers that haven't set one with SetShutdownTimeout.
+const DefaultShutdownTimeout = 30 * time.Second
+
+// Hook is a function run by the manager at a point in its lifecycle.
+type Hook func(ctx context.Context) error
+
+// OnStart - Registers a hook run before the manager's workers are started, hooks are run in the order registered
+//
+// If a hook returns an error the workers aren't started and Run returns the error.
+func (m *Manager) OnStart(hook Hook) {
+	m.startHooks = append(m.startHooks, hook)
+}
+
+// OnReady - Registers a hook run once the registration of every worker has been acknowledged by the Nitric server
+//
+// If a hook returns an error the manager shuts down and Run returns the error.
+func (m *Manager) OnReady(hook Hook) {
+	m.readyHooks = append(m.readyHooks, hook)
+}
+
+// OnShutdown - Registers a hook run after the manager's workers have stopped, hooks are run in the order registered
+//
+// The context passed to the hook is cancelled once the shutdown timeout elapses.
+func (m *Manager) OnShutdown(hook Hook) {
+	m.shutdownHooks = append(m.shutdownHooks, hook)
+}
+
+// SetShutdownTimeout - Sets how long the manager waits for in-flight messages to be handled and shutdown hooks to run once the Run context is cancelled
+func (m *Manager) SetShutdownTimeout(timeout time.Duration) {
+	m.shutdownTimeout = timeout
+}
+
+func (m *Manager) getShutdownTimeout() time.Duration {
+	if m.shutdownTimeout <= 0 {
+		return DefaultShutdownTimeout
+	}
+
+	return m.shutdownTimeout
+}
+
+// runHooks runs the hooks in order, stopping at the first error.
+func runHooks(ctx context.Context, hooks []Hook) error {
+	for _, hook := range hooks {
+		if err := hook(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+)
+
+var defaultLogger atomic.Pointer[slog.Logger]
+
+// SetDefaultLogger sets the logger used by managers that haven't set their own logger, and by workers that aren't run by a manager.
+// It defaults to slog.Default().
+func SetDefaultLogger(logger *slog.Logger) {
+	defaultLogger.Store(logger)
+}
+
+func getDefaultLogger() *slog.Logger {
+	if logger := defaultLogger.Load(); logger != nil {
+		return logger
+	}
+
+	return slog.Default()
+}
+
+// SetLogger - Sets the logger used by the manager and its workers, defaults to the logger set with SetDefaultLogger
+func (m *Manager) SetLogger(logger *slog.Logger) {
+	m.logger = logger
+}
+
+// Logger - Returns the logger used by the manager and its workers
+func (m *Manager) Logger() *slog.Logger {
+	if m == nil || m.logger == nil {
+		return getDefaultLogger()
+	}
+
+	return m.logger
+}
+
+// Logger returns the logger of the manager running the worker, with the worker's name as an attribute.
+// Workers that aren't run by a manager use the logger set with SetDefaultLogger.
+func Logger(ctx context.Context) *slog.Logger {
+	m, name := workerFromContext(ctx)
+	if name == "" {
+		return m.Logger()
+	}
+
+	return m.Logger().With("worker", name)
+}
@@ -0,0 +1,97 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workers
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Logger", func() {
+	var (
+		m   *Manager
+		buf *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		buf = &bytes.Buffer{}
+		m = New()
+		m.SetLogger(slog.New(slog.NewJSONHandler(buf, nil)))
+	})
+
+	It("should add the worker name to the manager's logger", func() {
+		ctx := withWorker(context.Background(), &workerContext{manager: m, name: "test-worker"})
+
+		Logger(ctx).Info("test")
+
+		Expect(buf.String()).To(ContainSubstring(`"worker":"test-worker"`))
+	})
+
+	When("the worker isn't run by a manager", func() {
+		It("should use the default logger", func() {
+			Expect(Logger(context.Background())).To(Equal(slog.Default()))
+		})
+	})
+
+	When("a default logger is set", func() {
+		var defaultBuf *bytes.Buffer
+
+		BeforeEach(func() {
+			defaultBuf = &bytes.Buffer{}
+			SetDefaultLogger(slog.New(slog.NewJSONHandler(defaultBuf, nil)))
+		})
+
+		AfterEach(func() {
+			SetDefaultLogger(nil)
+		})
+
+		It("should be used by managers without their own logger and workers without a manager", func() {
+			other := New()
+			ctx := withWorker(context.Background(), &workerContext{manager: other, name: "other-worker"})
+
+			Logger(ctx).Info("from manager")
+			Logger(context.Background()).Info("without manager")
+
+			Expect(defaultBuf.String()).To(ContainSubstring(`"msg":"from manager"`))
+			Expect(defaultBuf.String()).To(ContainSubstring(`"worker":"other-worker"`))
+			Expect(defaultBuf.String()).To(ContainSubstring(`"msg":"without manager"`))
+		})
+
+		It("should not replace a manager's own logger", func() {
+			m.Logger().Info("own logger")
+
+			Expect(buf.String()).To(ContainSubstring(`"msg":"own logger"`))
+			Expect(defaultBuf.String()).To(BeEmpty())
+		})
+	})
+
+	When("a handler panics without a panic handler set", func() {
+		It("should log the panic and stack trace", func() {
+			ctx := withWorker(context.Background(), &workerContext{manager: m, name: "test-worker"})
+
+			_ = Recover(ctx, func() error {
+				panic("boom")
+			})
+
+			Expect(buf.String()).To(ContainSubstring(`"msg":"handler panicked"`))
+			Expect(buf.String()).To(ContainSubstring(`"panic":"boom"`))
+			Expect(buf.String()).To(ContainSubstring(`"stack":`))
+		})
+	})
+})
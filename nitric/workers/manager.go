@@ -18,14 +18,19 @@ import (
 	"context"
 	"errors"
 	"io"
+	"log/slog"
 	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	multierror "github.com/missionMeteora/toolkit/errors"
+	"google.golang.org/grpc"
 
 	grpcx "github.com/nitrictech/go-sdk/internal/grpc"
 	apierrors "github.com/nitrictech/go-sdk/nitric/errors"
+	"github.com/nitrictech/go-sdk/nitric/errors/codes"
 	v1 "github.com/nitrictech/nitric/core/pkg/proto/resources/v1"
 )
 
@@ -37,7 +42,27 @@ type RegisterResult struct {
 type Manager struct {
 	workers map[string]StreamWorker
 
-	rsc v1.ResourcesClient
+	conn           grpc.ClientConnInterface
+	connMutex      sync.RWMutex
+	interceptors   *grpcx.Interceptors
+	rsc            v1.ResourcesClient
+	rscMutex       sync.Mutex
+	startupTimeout time.Duration
+
+	panicHandler PanicHandler
+
+	reconnectPolicy  *ReconnectPolicy
+	reconnectHandler ReconnectHandler
+
+	shutdownTimeout time.Duration
+	startHooks      []Hook
+	readyHooks      []Hook
+	shutdownHooks   []Hook
+
+	logger *slog.Logger
+
+	manifest *Manifest
+	offline  bool
 }
 
 var defaultManager = New()
@@ -51,27 +76,111 @@ func GetDefaultManager() *Manager {
 // resources.NewApi() and the like. These use a default manager instance.
 func New() *Manager {
 	return &Manager{
-		workers: map[string]StreamWorker{},
+		workers:      map[string]StreamWorker{},
+		interceptors: &grpcx.Interceptors{},
+		manifest:     newManifest(),
 	}
 }
 
 func (m *Manager) AddWorker(name string, s StreamWorker) {
 	m.workers[name] = s
+
+	if rw, ok := s.(RegisteredWorker); ok {
+		m.manifest.addRegistration(name, rw.Registration())
+	}
+}
+
+// RemoveWorker removes a worker added with AddWorker, it has no effect once the manager is running.
+func (m *Manager) RemoveWorker(name string) {
+	delete(m.workers, name)
+	m.manifest.removeRegistration(name)
+}
+
+// SetConnection - Sets the connection to the Nitric server used by the manager's resources, clients and workers
+//
+// Managers use the SDK's shared connection by default, set a connection to use a manager independently of it.
+// The connection must be set before resources are declared with the manager.
+func (m *Manager) SetConnection(conn grpc.ClientConnInterface) {
+	m.rscMutex.Lock()
+	defer m.rscMutex.Unlock()
+
+	m.connMutex.Lock()
+	defer m.connMutex.Unlock()
+
+	m.conn = conn
+	m.rsc = nil
+}
+
+// Connection returns the connection to the Nitric server used by the manager.
+//
+// Calls made on the connection run through the global interceptors, followed by the manager's interceptors.
+func (m *Manager) Connection() (grpc.ClientConnInterface, error) {
+	conn, err := m.rawConnection()
+	if err != nil {
+		return nil, err
+	}
+
+	return grpcx.Intercept(conn, m.interceptors), nil
 }
 
+func (m *Manager) rawConnection() (grpc.ClientConnInterface, error) {
+	m.connMutex.RLock()
+	conn := m.conn
+	m.connMutex.RUnlock()
+
+	if conn != nil {
+		return conn, nil
+	}
+
+	return grpcx.GetConnection()
+}
+
+// AddUnaryInterceptors - Adds interceptors that run around unary calls made by the manager's resources, clients and workers
+func (m *Manager) AddUnaryInterceptors(interceptors ...grpc.UnaryClientInterceptor) {
+	m.interceptors.AddUnary(interceptors...)
+}
+
+// AddStreamInterceptors - Adds interceptors that run around the streams opened by the manager's workers and clients
+func (m *Manager) AddStreamInterceptors(interceptors ...grpc.StreamClientInterceptor) {
+	m.interceptors.AddStream(interceptors...)
+}
+
+// resourceServiceClient returns the client used to declare resources, waiting for the Nitric server to become reachable when it is first created.
 func (m *Manager) resourceServiceClient() (v1.ResourcesClient, error) {
-	conn, err := grpcx.GetConnection()
+	m.rscMutex.Lock()
+	defer m.rscMutex.Unlock()
+
+	if m.rsc != nil {
+		return m.rsc, nil
+	}
+
+	conn, err := m.rawConnection()
 	if err != nil {
 		return nil, err
 	}
 
-	if m.rsc == nil {
-		m.rsc = v1.NewResourcesClient(conn)
+	if err := m.waitForServer(conn); err != nil {
+		return nil, err
 	}
+
+	m.rsc = v1.NewResourcesClient(grpcx.Intercept(conn, m.interceptors))
+
 	return m.rsc, nil
 }
 
 func (m *Manager) RegisterResource(request *v1.ResourceDeclareRequest) <-chan RegisterResult {
+	m.manifest.addResource(request)
+
+	if m.isOffline() {
+		registerResourceChan := make(chan RegisterResult, 1)
+		registerResourceChan <- RegisterResult{
+			Err:        nil,
+			Identifier: request.Id,
+		}
+
+		return registerResourceChan
+	}
+
 	registerResourceChan := make(chan RegisterResult)
 
 	go func() {
@@ -124,12 +233,19 @@ func functionResourceDeclareRequest(subject *v1.ResourceIdentifier, actions []v1
 }
 
 func (m *Manager) RegisterPolicy(res *v1.ResourceIdentifier, actions ...v1.Action) error {
+	request := functionResourceDeclareRequest(res, actions)
+	m.manifest.addResource(request)
+
+	if m.isOffline() {
+		return nil
+	}
+
 	rsc, err := m.resourceServiceClient()
 	if err != nil {
 		return err
 	}
 
-	_, err = rsc.Declare(context.Background(), functionResourceDeclareRequest(res, actions))
+	_, err = rsc.Declare(context.Background(), request)
 	if err != nil {
 		return err
 	}
@@ -137,27 +253,147 @@ func (m *Manager) RegisterPolicy(res *v1.ResourceIdentifier, actions ...v1.Actio
 	return nil
 }
 
+// Run starts all workers and blocks until they stop.
+//
+// The start hooks are run before the workers are started, and the ready hooks once every worker's registration is acknowledged.
+// When ctx is cancelled the workers stop receiving new messages, ShutdownSignal is closed and the workers wait for in-flight messages
+// to be handled, until the shutdown timeout elapses and the workers and their handler contexts are cancelled.
+// The shutdown hooks are run once the workers have stopped.
+// Hooks aren't run in the build environment, where the workers are only started to collect the application's resources.
+// In offline mode the workers aren't started, instead the manifest is written to the file set by NITRIC_MANIFEST, if any.
 func (m *Manager) Run(ctx context.Context) error {
+	if m.isOffline() {
+		return m.writeManifest()
+	}
+
 	wg := sync.WaitGroup{}
 	errList := &multierror.ErrorList{}
+	hooksEnabled := !isBuildEnvironment()
+
+	if hooksEnabled {
+		if err := runHooks(ctx, m.startHooks); err != nil {
+			return apierrors.NewWithCause(codes.Aborted, "Manager.Run: start hook failed, workers were not started", err)
+		}
+	}
+
+	// runCtx is cancelled to shut down the manager if a ready hook fails
+	runCtx, abort := context.WithCancel(ctx)
+	defer abort()
+
+	// workers aren't cancelled with ctx, so in-flight messages can be handled before the streams are closed
+	workerCtx, stopWorkers := context.WithCancel(context.WithoutCancel(ctx))
+	defer stopWorkers()
+
+	// shutdown is cancelled when shutdown starts, closing ShutdownSignal while the workers' streams and handler contexts stay open
+	shutdown, startShutdown := context.WithCancel(context.Background())
+	defer startShutdown()
+
+	pending := int32(len(m.workers))
+	allReady := make(chan struct{})
+	if pending == 0 {
+		close(allReady)
+	}
+
+	for name, worker := range m.workers {
+		var readyOnce sync.Once
+		wc := &workerContext{
+			manager:  m,
+			name:     name,
+			shutdown: shutdown,
+			ready: func() {
+				readyOnce.Do(func() {
+					if atomic.AddInt32(&pending, -1) == 0 {
+						close(allReady)
+					}
+				})
+			},
+		}
 
-	for _, worker := range m.workers {
 		wg.Add(1)
-		go func(s StreamWorker) {
+		go func(name string, s StreamWorker) {
 			defer wg.Done()
 
-			if err := s.Start(ctx); err != nil {
+			if err := s.Start(withWorker(workerCtx, wc)); err != nil {
 				if isBuildEnvironment() && isEOF(err) {
 					// ignore the EOF error when running code-as-config.
 					return
 				}
 
+				m.Logger().Error("worker stopped", "worker", name, "error", err)
 				errList.Push(err)
 			}
-		}(worker)
+		}(name, worker)
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(stopped)
+	}()
+
+	// readyDone is closed once the ready hooks have finished, or won't be run
+	readyDone := make(chan struct{})
+
+	if hooksEnabled {
+		go func() {
+			defer close(readyDone)
+
+			select {
+			case <-allReady:
+			case <-runCtx.Done():
+				return
+			case <-stopped:
+				// a worker stopped before registering, so not every worker will be ready
+				return
+			}
+
+			if err := runHooks(runCtx, m.readyHooks); err != nil {
+				errList.Push(apierrors.NewWithCause(codes.Aborted, "Manager.Run: ready hook failed, shutting down", err))
+				abort()
+			}
+		}()
+	} else {
+		close(readyDone)
 	}
 
-	wg.Wait()
+	timeout := m.getShutdownTimeout()
+	var shutdownDeadline time.Time
+
+	select {
+	case <-stopped:
+		shutdownDeadline = time.Now().Add(timeout)
+	case <-runCtx.Done():
+		m.Logger().Info("shutting down workers", "timeout", timeout)
+
+		shutdownDeadline = time.Now().Add(timeout)
+		startShutdown()
+
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+
+		select {
+		case <-stopped:
+		case <-timer.C:
+			m.Logger().Warn("shutdown timeout elapsed, stopping workers with in-flight messages")
+			stopWorkers()
+			<-stopped
+		}
+	}
+
+	// wait for running ready hooks, so they don't overlap with the shutdown hooks and their errors are returned
+	<-readyDone
+
+	if hooksEnabled {
+		hookCtx, cancel := context.WithDeadline(context.WithoutCancel(ctx), shutdownDeadline)
+		defer cancel()
+
+		// run every shutdown hook, so a failing hook doesn't prevent others from cleaning up
+		for _, hook := range m.shutdownHooks {
+			if err := hook(hookCtx); err != nil {
+				errList.Push(err)
+			}
+		}
+	}
 
 	return errList.Err()
 }
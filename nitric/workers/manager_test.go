@@ -0,0 +1,391 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workers
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+)
+
+// testWorker handles a single message with the handler, then waits for the stream to be closed.
+type testWorker struct {
+	handler func(msg *testServerMsg) (*testClientMsg, error)
+
+	mu     sync.Mutex
+	stream *testStream
+}
+
+func (t *testWorker) Start(ctx context.Context) error {
+	createStream := func(ctx context.Context) (Stream[testClientMsg, testRegistrationResponse, *testServerMsg], error) {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		t.stream = &testStream{
+			ctx: ctx,
+			results: []recvResult{
+				{msg: &testServerMsg{registration: &testRegistrationResponse{}}},
+				{msg: &testServerMsg{id: "1"}},
+			},
+		}
+
+		return t.stream, nil
+	}
+
+	return HandleStream(ctx, createStream, &testClientMsg{id: "init"}, t.handler)
+}
+
+func (t *testWorker) sent() []*testClientMsg {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.stream.sent
+}
+
+// contextWorker is a testWorker with a handler that receives a HandlerContext.
+type contextWorker struct {
+	testWorker
+	handler func(ctx context.Context, msg *testServerMsg) (*testClientMsg, error)
+}
+
+func (c *contextWorker) Start(ctx context.Context) error {
+	c.testWorker.handler = func(msg *testServerMsg) (*testClientMsg, error) {
+		handlerCtx, cancel := HandlerContext(ctx, 0)
+		defer cancel()
+
+		return c.handler(handlerCtx, msg)
+	}
+
+	return c.testWorker.Start(ctx)
+}
+
+var _ = Describe("Manager", func() {
+	Describe("Run()", func() {
+		var (
+			m       *Manager
+			started chan struct{}
+			release chan struct{}
+			worker  *testWorker
+		)
+
+		BeforeEach(func() {
+			// the handler may outlive the spec, so it uses its own copies of the channels
+			handlerStarted := make(chan struct{})
+			handlerRelease := make(chan struct{})
+			started, release = handlerStarted, handlerRelease
+
+			worker = &testWorker{
+				handler: func(msg *testServerMsg) (*testClientMsg, error) {
+					close(handlerStarted)
+					<-handlerRelease
+
+					return &testClientMsg{id: msg.id}, nil
+				},
+			}
+
+			m = New()
+			m.AddWorker("test-worker", worker)
+		})
+
+		When("the context is cancelled with a message in-flight", func() {
+			It("should wait for the message to be handled before returning", func() {
+				var hookCalled bool
+				m.OnShutdown(func(ctx context.Context) error {
+					hookCalled = true
+					return nil
+				})
+
+				ctx, cancel := context.WithCancel(context.Background())
+				runErr := make(chan error)
+				go func() {
+					runErr <- m.Run(ctx)
+				}()
+
+				Eventually(started).Should(BeClosed())
+				cancel()
+
+				Consistently(runErr, 50*time.Millisecond).ShouldNot(Receive())
+				close(release)
+
+				Eventually(runErr).Should(Receive(BeNil()))
+				Expect(worker.sent()).To(Equal([]*testClientMsg{{id: "init"}, {id: "1"}}))
+
+				By("running the shutdown hooks")
+				Expect(hookCalled).To(BeTrue())
+			})
+		})
+
+		When("a handler waits for the shutdown signal", func() {
+			It("should close the signal as soon as shutdown starts, leaving the handler context usable", func() {
+				close(release)
+
+				handlerStarted := make(chan struct{})
+				var handlerErr error
+				ctxWorker := &contextWorker{handler: func(ctx context.Context, msg *testServerMsg) (*testClientMsg, error) {
+					close(handlerStarted)
+					<-ShutdownSignal(ctx)
+					handlerErr = ctx.Err()
+
+					return &testClientMsg{id: msg.id}, nil
+				}}
+
+				m = New()
+				m.SetShutdownTimeout(time.Minute)
+				m.AddWorker("context-worker", ctxWorker)
+
+				ctx, cancel := context.WithCancel(context.Background())
+				runErr := make(chan error)
+				go func() {
+					runErr <- m.Run(ctx)
+				}()
+
+				Eventually(handlerStarted).Should(BeClosed())
+				cancel()
+
+				Eventually(runErr).Should(Receive(BeNil()))
+				Expect(handlerErr).ToNot(HaveOccurred())
+				Expect(ctxWorker.sent()).To(Equal([]*testClientMsg{{id: "init"}, {id: "1"}}))
+			})
+		})
+
+		When("a handler waits for its context to be cancelled", func() {
+			It("should cancel the handler context once the shutdown timeout elapses", func() {
+				close(release)
+
+				handlerStarted := make(chan struct{})
+				handlerDone := make(chan struct{})
+				ctxWorker := &contextWorker{handler: func(ctx context.Context, msg *testServerMsg) (*testClientMsg, error) {
+					defer close(handlerDone)
+					close(handlerStarted)
+					<-ctx.Done()
+
+					return &testClientMsg{id: msg.id}, nil
+				}}
+
+				m = New()
+				m.SetShutdownTimeout(100 * time.Millisecond)
+				m.AddWorker("context-worker", ctxWorker)
+
+				ctx, cancel := context.WithCancel(context.Background())
+				runErr := make(chan error)
+				go func() {
+					runErr <- m.Run(ctx)
+				}()
+
+				Eventually(handlerStarted).Should(BeClosed())
+				cancel()
+
+				Consistently(handlerDone, 50*time.Millisecond).ShouldNot(BeClosed())
+				Eventually(handlerDone).Should(BeClosed())
+				Eventually(runErr).Should(Receive(BeNil()))
+			})
+		})
+
+		When("in-flight messages take longer than the shutdown timeout", func() {
+			It("should stop the workers once the timeout elapses", func() {
+				defer close(release)
+
+				m.SetShutdownTimeout(50 * time.Millisecond)
+
+				ctx, cancel := context.WithCancel(context.Background())
+				runErr := make(chan error)
+				go func() {
+					runErr <- m.Run(ctx)
+				}()
+
+				Eventually(started).Should(BeClosed())
+				cancel()
+
+				Eventually(runErr).Should(Receive(BeNil()))
+				Expect(worker.sent()).To(Equal([]*testClientMsg{{id: "init"}}))
+			})
+		})
+
+		When("a shutdown hook fails", func() {
+			It("should return the error", func() {
+				close(release)
+				m.OnShutdown(func(ctx context.Context) error {
+					return errors.New("flush failed")
+				})
+
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+
+				Expect(m.Run(ctx)).To(MatchError(ContainSubstring("flush failed")))
+			})
+		})
+
+		When("a start hook fails", func() {
+			It("should return the error without starting the workers", func() {
+				close(release)
+				m.OnStart(func(ctx context.Context) error {
+					return errors.New("unable to connect to database")
+				})
+
+				err := m.Run(context.Background())
+
+				Expect(err).To(MatchError(ContainSubstring("unable to connect to database")))
+				Expect(worker.stream).To(BeNil())
+			})
+		})
+
+		When("every worker's registration is acknowledged", func() {
+			It("should run the ready hooks", func() {
+				defer close(release)
+
+				ready := make(chan struct{})
+				m.OnReady(func(ctx context.Context) error {
+					close(ready)
+					return nil
+				})
+
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+
+				go func() {
+					_ = m.Run(ctx)
+				}()
+
+				Eventually(ready).Should(BeClosed())
+			})
+		})
+
+		When("the workers stop while a ready hook is running", func() {
+			It("should wait for the ready hook before running the shutdown hooks", func() {
+				close(release)
+
+				var (
+					mu     sync.Mutex
+					events []string
+				)
+				record := func(event string) {
+					mu.Lock()
+					defer mu.Unlock()
+					events = append(events, event)
+				}
+
+				readyStarted := make(chan struct{})
+				readyRelease := make(chan struct{})
+				m.OnReady(func(ctx context.Context) error {
+					close(readyStarted)
+					<-readyRelease
+					record("ready")
+					return errors.New("cache warm up failed")
+				})
+				m.OnShutdown(func(ctx context.Context) error {
+					record("shutdown")
+					return nil
+				})
+
+				ctx, cancel := context.WithCancel(context.Background())
+				runErr := make(chan error)
+				go func() {
+					runErr <- m.Run(ctx)
+				}()
+
+				Eventually(readyStarted).Should(BeClosed())
+				cancel()
+
+				Consistently(runErr, 50*time.Millisecond).ShouldNot(Receive())
+				close(readyRelease)
+
+				Eventually(runErr).Should(Receive(MatchError(ContainSubstring("cache warm up failed"))))
+				Expect(events).To(Equal([]string{"ready", "shutdown"}))
+			})
+		})
+
+		When("a worker stops before its registration is acknowledged", func() {
+			It("should return the error without running the ready hooks", func() {
+				close(release)
+
+				var readyCalled bool
+				m = New()
+				m.OnReady(func(ctx context.Context) error {
+					readyCalled = true
+					return nil
+				})
+				m.AddWorker("failing-worker", failingWorker{err: errors.New("boom")})
+
+				runErr := make(chan error)
+				go func() {
+					runErr <- m.Run(context.Background())
+				}()
+
+				Eventually(runErr).Should(Receive(MatchError(ContainSubstring("boom"))))
+				Expect(readyCalled).To(BeFalse())
+			})
+		})
+
+		When("a ready hook fails", func() {
+			It("should shut down and return the error", func() {
+				close(release)
+				m.OnReady(func(ctx context.Context) error {
+					return errors.New("cache warm up failed")
+				})
+
+				runErr := make(chan error)
+				go func() {
+					runErr <- m.Run(context.Background())
+				}()
+
+				Eventually(runErr).Should(Receive(MatchError(ContainSubstring("cache warm up failed"))))
+			})
+		})
+	})
+
+	Describe("SetConnection()", func() {
+		It("should be safe to call while the connection is in use", func() {
+			m := New()
+			conn := &fakeConn{}
+
+			var wg sync.WaitGroup
+			for i := 0; i < 10; i++ {
+				wg.Add(2)
+				go func() {
+					defer wg.Done()
+					m.SetConnection(conn)
+				}()
+				go func() {
+					defer wg.Done()
+					_, _ = m.Connection()
+				}()
+			}
+			wg.Wait()
+
+			got, err := m.rawConnection()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(got).To(BeIdenticalTo(conn))
+		})
+	})
+})
+
+// failingWorker stops with err before its registration is acknowledged.
+type failingWorker struct {
+	err error
+}
+
+func (f failingWorker) Start(ctx context.Context) error {
+	return f.err
+}
+
+// fakeConn stands in for a connection to the Nitric server, it isn't used to make calls.
+type fakeConn struct {
+	grpc.ClientConnInterface
+}
@@ -0,0 +1,332 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workers
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+
+	apierrors "github.com/nitrictech/go-sdk/nitric/errors"
+	"github.com/nitrictech/go-sdk/nitric/errors/codes"
+
+	apispb "github.com/nitrictech/nitric/core/pkg/proto/apis/v1"
+	batchpb "github.com/nitrictech/nitric/core/pkg/proto/batch/v1"
+	v1 "github.com/nitrictech/nitric/core/pkg/proto/resources/v1"
+	schedulespb "github.com/nitrictech/nitric/core/pkg/proto/schedules/v1"
+	storagepb "github.com/nitrictech/nitric/core/pkg/proto/storage/v1"
+	topicspb "github.com/nitrictech/nitric/core/pkg/proto/topics/v1"
+	websocketspb "github.com/nitrictech/nitric/core/pkg/proto/websockets/v1"
+)
+
+// RegisteredWorker is implemented by workers that register with the Nitric server, so their registration is included in the manifest.
+type RegisteredWorker interface {
+	StreamWorker
+	// Registration returns the registration request the worker sends when it starts.
+	Registration() proto.Message
+}
+
+// Manifest records the resources, policies and worker registrations declared by an application.
+type Manifest struct {
+	mu            sync.Mutex
+	resources     []*v1.ResourceDeclareRequest
+	registrations map[string]proto.Message
+}
+
+func newManifest() *Manifest {
+	return &Manifest{
+		registrations: map[string]proto.Message{},
+	}
+}
+
+func (m *Manifest) addResource(request *v1.ResourceDeclareRequest) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// resources can be declared more than once, e.g. when requesting permissions to a resource more than once
+	for _, existing := range m.resources {
+		if proto.Equal(existing, request) {
+			return
+		}
+	}
+
+	m.resources = append(m.resources, request)
+}
+
+func (m *Manifest) addRegistration(name string, registration proto.Message) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.registrations[name] = registration
+}
+
+func (m *Manifest) removeRegistration(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.registrations, name)
+}
+
+// Resources returns the resource and policy declarations, in the order they were declared.
+func (m *Manifest) Resources() []*v1.ResourceDeclareRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]*v1.ResourceDeclareRequest{}, m.resources...)
+}
+
+// Registrations returns the registration requests of the workers, keyed by worker name.
+func (m *Manifest) Registrations() map[string]proto.Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	registrations := make(map[string]proto.Message, len(m.registrations))
+	for name, registration := range m.registrations {
+		registrations[name] = registration
+	}
+
+	return registrations
+}
+
+var resourceSections = map[v1.ResourceType]string{
+	v1.ResourceType_Api:                   "apis",
+	v1.ResourceType_ApiSecurityDefinition: "securityDefinitions",
+	v1.ResourceType_Bucket:                "buckets",
+	v1.ResourceType_Topic:                 "topics",
+	v1.ResourceType_KeyValueStore:         "keyValueStores",
+	v1.ResourceType_Queue:                 "queues",
+	v1.ResourceType_Secret:                "secrets",
+	v1.ResourceType_SqlDatabase:           "sqlDatabases",
+	v1.ResourceType_Job:                   "jobs",
+	v1.ResourceType_Websocket:             "websockets",
+	v1.ResourceType_Http:                  "http",
+	v1.ResourceType_Policy:                "policies",
+}
+
+func registrationSection(registration proto.Message) string {
+	switch registration.(type) {
+	case *apispb.RegistrationRequest:
+		return "routes"
+	case *topicspb.RegistrationRequest:
+		return "subscriptions"
+	case *schedulespb.RegistrationRequest:
+		return "schedules"
+	case *storagepb.RegistrationRequest:
+		return "bucketListeners"
+	case *batchpb.RegistrationRequest:
+		return "jobHandlers"
+	case *websocketspb.RegistrationRequest:
+		return "websocketHandlers"
+	default:
+		return "workers"
+	}
+}
+
+// resourceConfig returns the config of the declared resource, the message set in the request's config oneof.
+func resourceConfig(request *v1.ResourceDeclareRequest) proto.Message {
+	oneof := request.ProtoReflect().Descriptor().Oneofs().ByName("config")
+	field := request.ProtoReflect().WhichOneof(oneof)
+	if field == nil {
+		return nil
+	}
+
+	return request.ProtoReflect().Get(field).Message().Interface()
+}
+
+func toEntry(message proto.Message, extra map[string]interface{}) (map[string]interface{}, error) {
+	entry := map[string]interface{}{}
+
+	if message != nil {
+		b, err := protojson.Marshal(message)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(b, &entry); err != nil {
+			return nil, err
+		}
+	}
+
+	for k, v := range extra {
+		entry[k] = v
+	}
+
+	return entry, nil
+}
+
+// document returns the manifest grouped into sections by resource and worker type.
+// Entries are sorted within their sections, so the manifest is stable between runs.
+func (m *Manifest) document() (map[string][]map[string]interface{}, error) {
+	doc := map[string][]map[string]interface{}{}
+
+	for _, request := range m.Resources() {
+		section, ok := resourceSections[request.GetId().GetType()]
+		if !ok {
+			section = strings.ToLower(request.GetId().GetType().String())
+		}
+
+		extra := map[string]interface{}{}
+		if name := request.GetId().GetName(); name != "" {
+			extra["name"] = name
+		}
+
+		entry, err := toEntry(resourceConfig(request), extra)
+		if err != nil {
+			return nil, err
+		}
+
+		doc[section] = append(doc[section], entry)
+	}
+
+	for name, registration := range m.Registrations() {
+		entry, err := toEntry(registration, map[string]interface{}{"worker": name})
+		if err != nil {
+			return nil, err
+		}
+
+		section := registrationSection(registration)
+		doc[section] = append(doc[section], entry)
+	}
+
+	for _, entries := range doc {
+		keys := make([]string, len(entries))
+		for i, entry := range entries {
+			b, err := json.Marshal(entry)
+			if err != nil {
+				return nil, err
+			}
+			keys[i] = string(b)
+		}
+
+		sort.Sort(byKey{entries: entries, keys: keys})
+	}
+
+	return doc, nil
+}
+
+type byKey struct {
+	entries []map[string]interface{}
+	keys    []string
+}
+
+func (b byKey) Len() int           { return len(b.entries) }
+func (b byKey) Less(i, j int) bool { return b.keys[i] < b.keys[j] }
+func (b byKey) Swap(i, j int) {
+	b.entries[i], b.entries[j] = b.entries[j], b.entries[i]
+	b.keys[i], b.keys[j] = b.keys[j], b.keys[i]
+}
+
+// MarshalJSON returns the manifest as JSON, grouped into sections such as "apis", "routes" and "policies".
+func (m *Manifest) MarshalJSON() ([]byte, error) {
+	doc, err := m.document()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(doc)
+}
+
+// JSON returns the manifest as indented JSON.
+func (m *Manifest) JSON() ([]byte, error) {
+	b, err := m.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, b, "", "  "); err != nil {
+		return nil, err
+	}
+	out.WriteByte('\n')
+
+	return out.Bytes(), nil
+}
+
+// YAML returns the manifest as YAML, with the same structure as the JSON manifest.
+func (m *Manifest) YAML() ([]byte, error) {
+	doc, err := m.document()
+	if err != nil {
+		return nil, err
+	}
+
+	return yaml.Marshal(doc)
+}
+
+// WriteFile writes the manifest to the file, as YAML if the file has a .yaml or .yml extension, otherwise as JSON.
+func (m *Manifest) WriteFile(path string) error {
+	var (
+		b   []byte
+		err error
+	)
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		b, err = m.YAML()
+	default:
+		b, err = m.JSON()
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, b, 0o644)
+}
+
+// Manifest returns the manifest of the resources, policies and workers registered with the manager.
+func (m *Manager) Manifest() *Manifest {
+	return m.manifest
+}
+
+// SetOffline - Sets whether the manager runs offline, defaults to the NITRIC_OFFLINE environment variable
+//
+// Offline, resources and policies are only recorded in the manifest instead of being declared with the Nitric server,
+// and Run doesn't start the workers. This allows the manifest to be exported without a Nitric server, e.g. in CI or unit tests.
+func (m *Manager) SetOffline(offline bool) {
+	m.offline = offline
+}
+
+func (m *Manager) isOffline() bool {
+	if m.offline {
+		return true
+	}
+
+	offline, _ := strconv.ParseBool(os.Getenv("NITRIC_OFFLINE"))
+	return offline
+}
+
+func (m *Manager) writeManifest() error {
+	path := os.Getenv("NITRIC_MANIFEST")
+	if path == "" {
+		m.Logger().Info("running offline, workers were not started")
+		return nil
+	}
+
+	if err := m.manifest.WriteFile(path); err != nil {
+		return apierrors.NewWithCause(codes.Internal, "Manager.Run: unable to write manifest", err)
+	}
+
+	m.Logger().Info("running offline, wrote manifest", "path", path)
+
+	return nil
+}
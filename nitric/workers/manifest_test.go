@@ -0,0 +1,148 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+
+	apispb "github.com/nitrictech/nitric/core/pkg/proto/apis/v1"
+	v1 "github.com/nitrictech/nitric/core/pkg/proto/resources/v1"
+	topicspb "github.com/nitrictech/nitric/core/pkg/proto/topics/v1"
+)
+
+// registeredTestWorker fails if it is started, as workers shouldn't be started offline.
+type registeredTestWorker struct {
+	registration proto.Message
+}
+
+func (r *registeredTestWorker) Start(ctx context.Context) error {
+	Fail("worker was started while offline")
+	return nil
+}
+
+func (r *registeredTestWorker) Registration() proto.Message {
+	return r.registration
+}
+
+var _ = Describe("Manifest", func() {
+	var m *Manager
+
+	topic := &v1.ResourceIdentifier{Type: v1.ResourceType_Topic, Name: "updates"}
+
+	BeforeEach(func() {
+		m = New()
+		m.SetOffline(true)
+
+		result := <-m.RegisterResource(&v1.ResourceDeclareRequest{
+			Id: topic,
+			Config: &v1.ResourceDeclareRequest_Topic{
+				Topic: &v1.TopicResource{},
+			},
+		})
+		Expect(result.Err).ToNot(HaveOccurred())
+		Expect(result.Identifier).To(Equal(topic))
+
+		Expect(m.RegisterPolicy(topic, v1.Action_TopicPublish)).To(Succeed())
+		// duplicate declarations are only recorded once
+		Expect(m.RegisterPolicy(topic, v1.Action_TopicPublish)).To(Succeed())
+
+		m.AddWorker("SubscriptionWorker:updates", &registeredTestWorker{
+			registration: &topicspb.RegistrationRequest{TopicName: "updates"},
+		})
+		m.AddWorker("route:main", &registeredTestWorker{
+			registration: &apispb.RegistrationRequest{Api: "main", Path: "/orders", Methods: []string{"GET"}},
+		})
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("NITRIC_MANIFEST")
+	})
+
+	It("should record the declared resources and policies", func() {
+		resources := m.Manifest().Resources()
+
+		Expect(resources).To(HaveLen(2))
+		Expect(resources[0].GetId()).To(Equal(topic))
+		Expect(resources[1].GetPolicy().GetActions()).To(Equal([]v1.Action{v1.Action_TopicPublish}))
+	})
+
+	It("should record the registrations of added workers", func() {
+		registrations := m.Manifest().Registrations()
+
+		Expect(registrations).To(HaveLen(2))
+		Expect(registrations).To(HaveKey("SubscriptionWorker:updates"))
+		Expect(registrations).To(HaveKey("route:main"))
+
+		m.RemoveWorker("route:main")
+		Expect(m.Manifest().Registrations()).ToNot(HaveKey("route:main"))
+	})
+
+	It("should group the manifest into sections", func() {
+		b, err := json.Marshal(m.Manifest())
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(b).To(MatchJSON(`{
+			"topics": [{"name": "updates"}],
+			"policies": [{
+				"principals": [{"type": "Service"}],
+				"actions": ["TopicPublish"],
+				"resources": [{"type": "Topic", "name": "updates"}]
+			}],
+			"subscriptions": [{"worker": "SubscriptionWorker:updates", "topicName": "updates"}],
+			"routes": [{"worker": "route:main", "api": "main", "path": "/orders", "methods": ["GET"]}]
+		}`))
+	})
+
+	It("should export the same manifest as YAML", func() {
+		b, err := m.Manifest().YAML()
+		Expect(err).ToNot(HaveOccurred())
+
+		var fromYAML map[string]interface{}
+		Expect(yaml.Unmarshal(b, &fromYAML)).To(Succeed())
+
+		j, err := m.Manifest().JSON()
+		Expect(err).ToNot(HaveOccurred())
+
+		fromYAMLJSON, err := json.Marshal(fromYAML)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fromYAMLJSON).To(MatchJSON(j))
+	})
+
+	It("should write the manifest instead of starting workers when run", func() {
+		dir, err := os.MkdirTemp("", "manifest")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "manifest.yaml")
+		os.Setenv("NITRIC_MANIFEST", path)
+
+		Expect(m.Run(context.Background())).To(Succeed())
+
+		b, err := os.ReadFile(path)
+		Expect(err).ToNot(HaveOccurred())
+
+		expected, err := m.Manifest().YAML()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(b).To(Equal(expected))
+	})
+})
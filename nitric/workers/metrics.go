@@ -0,0 +1,30 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workers
+
+import (
+	"context"
+
+	"github.com/nitrictech/go-sdk/nitric/metrics"
+)
+
+// Observe starts measuring a handler call by the worker running in ctx, returning a function to call with the handler's result.
+//
+// Worker metrics are keyed by the name the worker was added to the manager with.
+func Observe(ctx context.Context) func(err error) {
+	_, name := workerFromContext(ctx)
+
+	return metrics.Start(metrics.Worker, name, "handle")
+}
@@ -0,0 +1,139 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workers
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ReconnectPolicy configures how workers re-create their stream to the Nitric server when it fails.
+type ReconnectPolicy struct {
+	// Disabled stops workers from reconnecting, the stream error is returned from Run instead.
+	Disabled bool
+	// InitialBackoff is the delay before the first reconnection attempt.
+	InitialBackoff time.Duration
+	// MaxBackoff is the upper limit of the delay between reconnection attempts.
+	MaxBackoff time.Duration
+	// Multiplier is the factor the delay is increased by after each failed attempt.
+	Multiplier float64
+	// Jitter is the fraction, between 0 and 1, the delay is randomly varied by to spread out reconnecting workers.
+	Jitter float64
+	// MaxAttempts is the number of consecutive failed attempts before giving up, zero allows unlimited attempts.
+	MaxAttempts int
+}
+
+// DefaultReconnectPolicy is the reconnect policy used by managers that haven't set one with SetReconnectPolicy.
+var DefaultReconnectPolicy = ReconnectPolicy{
+	InitialBackoff: time.Second,
+	MaxBackoff:     30 * time.Second,
+	Multiplier:     1.6,
+	Jitter:         0.2,
+}
+
+// backoff returns the delay before the given reconnection attempt, starting from 1.
+func (p ReconnectPolicy) backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	delay := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+
+	if p.Jitter > 0 {
+		delay *= 1 + p.Jitter*(rand.Float64()*2-1)
+	}
+
+	return time.Duration(delay)
+}
+
+// ReconnectEvent describes a worker reconnecting to the Nitric server after its stream failed.
+type ReconnectEvent struct {
+	// Worker is the name of the reconnecting worker.
+	Worker string
+	// Attempt is the number of consecutive reconnection attempts, starting from 1.
+	Attempt int
+	// Delay is how long the worker waits before reconnecting.
+	Delay time.Duration
+	// Err is the error that caused the stream to fail.
+	Err error
+}
+
+// ReconnectHandler is called each time a worker schedules a reconnection attempt.
+type ReconnectHandler func(event ReconnectEvent)
+
+// SetReconnectPolicy - Sets the policy used by the manager's workers to reconnect when their stream fails
+func (m *Manager) SetReconnectPolicy(policy ReconnectPolicy) {
+	m.reconnectPolicy = &policy
+}
+
+// OnReconnect - Sets the handler called when a worker schedules a reconnection attempt, replacing the default which logs the event
+func (m *Manager) OnReconnect(handler ReconnectHandler) {
+	m.reconnectHandler = handler
+}
+
+func (m *Manager) getReconnectPolicy() ReconnectPolicy {
+	if m == nil || m.reconnectPolicy == nil {
+		return DefaultReconnectPolicy
+	}
+
+	return *m.reconnectPolicy
+}
+
+func (m *Manager) emitReconnect(event ReconnectEvent) {
+	if m == nil || m.reconnectHandler == nil {
+		m.Logger().Warn("stream failed, reconnecting",
+			"worker", event.Worker,
+			"attempt", event.Attempt,
+			"delay", event.Delay,
+			"error", event.Err,
+		)
+		return
+	}
+
+	m.reconnectHandler(event)
+}
+
+// streamError is an error from creating, sending to or receiving from a stream, which may be recovered from by reconnecting.
+type streamError struct {
+	err error
+}
+
+func (s *streamError) Error() string {
+	return s.err.Error()
+}
+
+func (s *streamError) Unwrap() error {
+	return s.err
+}
+
+// isRetryable returns true if the stream error is transient.
+// Server-side failures such as Unknown and Internal, and local cancellation, are not retried so they are returned from Run
+// rather than reconnecting indefinitely with the default policy.
+func isRetryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.Aborted, codes.ResourceExhausted, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workers
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError is returned when a handler panics while processing a message.
+type PanicError struct {
+	// Worker is the name of the worker the panic occurred in.
+	Worker string
+	// Value is the value passed to panic.
+	Value interface{}
+	// Stack is the stack trace of the goroutine at the time of the panic.
+	Stack []byte
+}
+
+func (p *PanicError) Error() string {
+	if p.Worker == "" {
+		return fmt.Sprintf("handler panicked: %v", p.Value)
+	}
+
+	return fmt.Sprintf("handler for %s panicked: %v", p.Worker, p.Value)
+}
+
+// PanicHandler is called with the recovered panic when a handler panics.
+type PanicHandler func(err *PanicError)
+
+// OnPanic - Sets the handler called when a worker handler panics, replacing the default which logs the panic and stack trace
+func (m *Manager) OnPanic(handler PanicHandler) {
+	m.panicHandler = handler
+}
+
+// Recover calls the handler, converting a panic into a PanicError which is reported to the manager's panic handler.
+//
+// Workers use Recover when dispatching messages, so a panicking handler fails the message instead of the process.
+func Recover(ctx context.Context, handler func() error) (err error) {
+	defer func() {
+		value := recover()
+		if value == nil {
+			return
+		}
+
+		m, name := workerFromContext(ctx)
+
+		panicErr := &PanicError{
+			Worker: name,
+			Value:  value,
+			Stack:  debug.Stack(),
+		}
+
+		if m != nil && m.panicHandler != nil {
+			m.panicHandler(panicErr)
+		} else {
+			Logger(ctx).Error("handler panicked", "panic", fmt.Sprint(value), "stack", string(panicErr.Stack))
+		}
+
+		err = panicErr
+	}()
+
+	return handler()
+}
@@ -0,0 +1,75 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workers
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Recover", func() {
+	When("the handler returns", func() {
+		It("should return the handler's error", func() {
+			handlerErr := errors.New("handler error")
+
+			err := Recover(context.Background(), func() error {
+				return handlerErr
+			})
+
+			Expect(err).To(Equal(handlerErr))
+		})
+	})
+
+	When("the handler panics", func() {
+		var m *Manager
+		var reported *PanicError
+
+		BeforeEach(func() {
+			reported = nil
+			m = New()
+			m.OnPanic(func(err *PanicError) {
+				reported = err
+			})
+		})
+
+		It("should return a PanicError", func() {
+			ctx := withWorker(context.Background(), &workerContext{manager: m, name: "test-worker"})
+
+			err := Recover(ctx, func() error {
+				panic("boom")
+			})
+
+			var panicErr *PanicError
+			Expect(errors.As(err, &panicErr)).To(BeTrue())
+			Expect(panicErr.Value).To(Equal("boom"))
+			Expect(panicErr.Worker).To(Equal("test-worker"))
+			Expect(string(panicErr.Stack)).To(ContainSubstring("recover_test.go"))
+		})
+
+		It("should report the panic to the manager's panic handler", func() {
+			ctx := withWorker(context.Background(), &workerContext{manager: m, name: "test-worker"})
+
+			err := Recover(ctx, func() error {
+				panic("boom")
+			})
+
+			Expect(reported).ToNot(BeNil())
+			Expect(reported).To(Equal(err))
+		})
+	})
+})
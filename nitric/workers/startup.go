@@ -0,0 +1,88 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+
+	"github.com/nitrictech/go-sdk/constants"
+	apierrors "github.com/nitrictech/go-sdk/nitric/errors"
+	"github.com/nitrictech/go-sdk/nitric/errors/codes"
+)
+
+// SetStartupTimeout - Sets how long to wait for the Nitric server to become reachable before declaring resources, defaults to SERVICE_DIAL_TIMEOUT or 5 seconds
+func (m *Manager) SetStartupTimeout(timeout time.Duration) {
+	m.startupTimeout = timeout
+}
+
+func (m *Manager) getStartupTimeout() time.Duration {
+	if m.startupTimeout <= 0 {
+		return constants.NitricDialTimeout()
+	}
+
+	return m.startupTimeout
+}
+
+// watchableConn is implemented by *grpc.ClientConn.
+type watchableConn interface {
+	Connect()
+	GetState() connectivity.State
+	WaitForStateChange(ctx context.Context, sourceState connectivity.State) bool
+	Target() string
+}
+
+// waitForServer blocks until the connection to the Nitric server is ready, or the startup timeout elapses.
+//
+// While waiting the connection is retried with the backoff from its connect params.
+// Connections that can't be watched, such as fakes used in tests, are assumed to be ready.
+func (m *Manager) waitForServer(conn grpc.ClientConnInterface) error {
+	watched, ok := conn.(watchableConn)
+	if !ok {
+		return nil
+	}
+
+	timeout := m.getStartupTimeout()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	for {
+		state := watched.GetState()
+
+		switch state {
+		case connectivity.Ready:
+			return nil
+		case connectivity.Idle:
+			watched.Connect()
+		case connectivity.Shutdown:
+			return apierrors.New(codes.Unavailable, "unable to reach the Nitric server, the connection has been closed")
+		case connectivity.TransientFailure:
+			m.Logger().Debug("waiting for the Nitric server", "target", watched.Target())
+		}
+
+		if !watched.WaitForStateChange(ctx, state) {
+			return apierrors.NewWithCause(
+				codes.Unavailable,
+				fmt.Sprintf("timed out after %s waiting for the Nitric server at %s, check that it is running and reachable from SERVICE_ADDRESS", timeout, watched.Target()),
+				ctx.Err(),
+			)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workers
+
+import (
+	"context"
+	"net"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	v1 "github.com/nitrictech/nitric/core/pkg/proto/resources/v1"
+)
+
+type declaringResourcesServer struct {
+	v1.UnimplementedResourcesServer
+}
+
+func (declaringResourcesServer) Declare(context.Context, *v1.ResourceDeclareRequest) (*v1.ResourceDeclareResponse, error) {
+	return &v1.ResourceDeclareResponse{}, nil
+}
+
+var _ = Describe("startup", func() {
+	var (
+		address string
+		conn    *grpc.ClientConn
+		m       *Manager
+	)
+
+	BeforeEach(func() {
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).ToNot(HaveOccurred())
+		address = lis.Addr().String()
+		Expect(lis.Close()).To(Succeed())
+
+		conn, err = grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		Expect(err).ToNot(HaveOccurred())
+
+		m = New()
+		m.SetConnection(conn)
+	})
+
+	AfterEach(func() {
+		conn.Close()
+	})
+
+	request := &v1.ResourceDeclareRequest{
+		Id: &v1.ResourceIdentifier{Name: "test", Type: v1.ResourceType_Topic},
+	}
+
+	When("the Nitric server never becomes reachable", func() {
+		It("should fail with a timeout error", func() {
+			m.SetStartupTimeout(200 * time.Millisecond)
+
+			result := <-m.RegisterResource(request)
+
+			Expect(result.Err).To(HaveOccurred())
+			Expect(result.Err.Error()).To(ContainSubstring("timed out after 200ms waiting for the Nitric server"))
+			Expect(result.Err.Error()).To(ContainSubstring(address))
+		})
+	})
+
+	When("the Nitric server starts after the manager", func() {
+		It("should wait for it and declare the resource", func() {
+			m.SetStartupTimeout(10 * time.Second)
+
+			srv := grpc.NewServer()
+			v1.RegisterResourcesServer(srv, declaringResourcesServer{})
+			defer srv.Stop()
+
+			go func() {
+				defer GinkgoRecover()
+				time.Sleep(300 * time.Millisecond)
+
+				lis, err := net.Listen("tcp", address)
+				Expect(err).ToNot(HaveOccurred())
+				_ = srv.Serve(lis)
+			}()
+
+			result := <-m.RegisterResource(request)
+
+			Expect(result.Err).ToNot(HaveOccurred())
+			Expect(result.Identifier).To(Equal(request.Id))
+		})
+	})
+})
@@ -17,8 +17,9 @@ package workers
 import (
 	"context"
 	"errors"
-	"fmt"
 	"io"
+	"sync"
+	"time"
 
 	"google.golang.org/grpc"
 )
@@ -37,64 +38,329 @@ type Stream[ClientMessage any, RegistrationResponse any, ServerMessage StdServer
 	grpc.ClientStream
 }
 
+// HandlerContext returns the context for handling a single message, derived from the worker context.
+// The context is cancelled when the worker stops, which for a worker run by a manager is once the shutdown timeout elapses,
+// or when the timeout elapses if it is greater than zero. Use ShutdownSignal to find out when the manager starts shutting down.
+func HandlerContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+
+	return context.WithCancel(ctx)
+}
+
+// StreamOption configures how HandleStream dispatches messages.
+type StreamOption func(opts *streamOptions)
+
+type streamOptions struct {
+	maxInFlight int
+}
+
+// WithMaxInFlight - Set the maximum number of messages handled concurrently, defaults to 1 which handles messages in the order received
+func WithMaxInFlight(maxInFlight int) StreamOption {
+	return func(opts *streamOptions) {
+		opts.maxInFlight = maxInFlight
+	}
+}
+
 // HandleStream runs a nitric worker, in the standard request/response pattern.
-// No changes needed here other than the updated types in the signature.
+//
+// If the stream fails with a transient error the stream is re-created and the registration request resent,
+// with exponential backoff between attempts configured by the manager's ReconnectPolicy.
+// HandleStream returns nil when the context is cancelled or the server closes the stream.
+// When run by a Manager, HandleStream stops once the manager starts shutting down and in-flight messages have been handled,
+// messages received while waiting are still handled so each gets a response, until the context is cancelled.
+//
+// Messages are passed to handleServerMsg concurrently, up to the limit set with WithMaxInFlight,
+// and the responses are sent as each call completes.
 func HandleStream[ClientMessage any, RegistrationResponse any, ServerMessage StdServerMsg[RegistrationResponse]](
 	ctx context.Context,
 	createStream func(ctx context.Context) (Stream[ClientMessage, RegistrationResponse, ServerMessage], error),
 	initReq *ClientMessage,
 	handleServerMsg func(msg ServerMessage) (*ClientMessage, error),
+	opts ...StreamOption,
 ) error {
-	stream, err := createStream(ctx)
-	if err != nil {
-		return err
+	options := &streamOptions{
+		maxInFlight: 1,
 	}
 
-	err = stream.Send(initReq)
-	if err != nil {
-		return err
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if options.maxInFlight < 1 {
+		options.maxInFlight = 1
 	}
 
+	m, name := workerFromContext(ctx)
+	policy := m.getReconnectPolicy()
+	shutdown := shutdownSignal(ctx)
+
+	attempt := 0
 	for {
+		connected, err := runStream(ctx, shutdown, createStream, initReq, handleServerMsg, options)
+		if err == nil || ctx.Err() != nil || isClosed(shutdown) {
+			return nil
+		}
+
+		var streamErr *streamError
+		if !errors.As(err, &streamErr) {
+			return err
+		}
+
+		if policy.Disabled || !isRetryable(streamErr.err) {
+			return streamErr.err
+		}
+
+		// reset the backoff once a stream has been established
+		if connected {
+			attempt = 0
+		}
+
+		attempt++
+		if policy.MaxAttempts > 0 && attempt > policy.MaxAttempts {
+			return streamErr.err
+		}
+
+		delay := policy.backoff(attempt)
+
+		m.emitReconnect(ReconnectEvent{
+			Worker:  name,
+			Attempt: attempt,
+			Delay:   delay,
+			Err:     streamErr.err,
+		})
+
+		timer := time.NewTimer(delay)
 		select {
 		case <-ctx.Done():
-			fmt.Printf("Context canceled, closing stream\n")
-			// If the context is canceled, close the stream and return
-			err := stream.CloseSend()
+			timer.Stop()
+			return nil
+		case <-shutdown:
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+	}
+}
+
+// runStream creates a stream and handles messages until the stream is closed or fails, or shutdown is closed.
+// connected is true if a message was received from the server, confirming the stream was established.
+func runStream[ClientMessage any, RegistrationResponse any, ServerMessage StdServerMsg[RegistrationResponse]](
+	ctx context.Context,
+	shutdown <-chan struct{},
+	createStream func(ctx context.Context) (Stream[ClientMessage, RegistrationResponse, ServerMessage], error),
+	initReq *ClientMessage,
+	handleServerMsg func(msg ServerMessage) (*ClientMessage, error),
+	options *streamOptions,
+) (connected bool, err error) {
+	// streamCtx is cancelled to stop receiving when a message can't be handled
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	logger := Logger(ctx)
+
+	stream, err := createStream(streamCtx)
+	if err != nil {
+		return false, &streamError{err}
+	}
+
+	logger.Debug("stream opened")
+	defer logger.Debug("stream closed")
+
+	err = stream.Send(initReq)
+	if err != nil {
+		return false, &streamError{err}
+	}
+
+	var (
+		sendLock sync.Mutex
+		errOnce  sync.Once
+		// handlerErr is the first error from handling a message or sending its response
+		handlerErr error
+		inFlight   = make(chan struct{}, options.maxInFlight)
+		// completed receives a value as each handled message releases its slot, it is read whenever the receiving loop waits
+		completed = make(chan struct{}, options.maxInFlight)
+		// pending is the number of messages being handled, it is only accessed by the receiving loop
+		pending int
+	)
+
+	fail := func(err error) {
+		errOnce.Do(func() {
+			handlerErr = err
+			cancel()
+		})
+	}
+
+	handle := func(serverMsg ServerMessage) {
+		defer func() {
+			<-inFlight
+			completed <- struct{}{}
+		}()
+
+		clientMsg, err := handleServerMsg(serverMsg)
+		if err != nil {
+			fail(err)
+			return
+		}
+
+		// gRPC streams don't support concurrent calls to Send
+		sendLock.Lock()
+		defer sendLock.Unlock()
+
+		err = stream.Send(clientMsg)
+		if err != nil {
+			fail(&streamError{err})
+		}
+	}
+
+	// waitInFlight waits for in-flight messages to be handled, returning false if ctx is cancelled first
+	waitInFlight := func() bool {
+		for pending > 0 {
+			select {
+			case <-completed:
+				pending--
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		return true
+	}
+
+	// dispatch handles the message once there's a free slot, waiting for one even once shutdown starts so the message still gets a response
+	dispatch := func(msg ServerMessage) {
+		if msg.GetRegistrationResponse() != nil {
+			// No need to respond to the registration responses (they're just acks)
+			logger.Info("worker registered")
+			markReady(ctx)
+			return
+		}
+
+		for {
+			select {
+			case inFlight <- struct{}{}:
+				pending++
+				go handle(msg)
+				return
+			case <-completed:
+				pending--
+			case <-streamCtx.Done():
+				return
+			}
+		}
+	}
+
+	type recvResult struct {
+		msg ServerMessage
+		err error
+	}
+
+	// receive in a separate goroutine, so receiving can be stopped on shutdown without closing the stream
+	received := make(chan recvResult)
+	go func() {
+		for {
+			msg, err := stream.Recv()
+
+			select {
+			case received <- recvResult{msg, err}:
+			case <-streamCtx.Done():
+				return
+			}
+
 			if err != nil {
-				return err
+				return
 			}
-			return nil
+		}
+	}()
+
+	// drain handles messages that are received while waiting for in-flight messages, so none are left without a response,
+	// returning false if ctx is cancelled first
+	drain := func() bool {
+		for {
+			// handle a message that has already been received before checking if the in-flight messages are done
+			select {
+			case result := <-received:
+				if result.err != nil {
+					return waitInFlight()
+				}
 
-		default:
-			// Receive the next message
-			serverMsg, err := stream.Recv()
+				dispatch(result.msg)
+				continue
+			default:
+			}
+
+			if pending == 0 {
+				return true
+			}
 
-			if errors.Is(err, io.EOF) {
-				// Close the stream and exit normally on EOF
-				err = stream.CloseSend()
-				if err != nil {
-					return err
+			select {
+			case <-completed:
+				pending--
+			case <-ctx.Done():
+				return false
+			case result := <-received:
+				if result.err != nil {
+					return waitInFlight()
 				}
-				return nil
-			} else if err != nil {
-				return err
+
+				dispatch(result.msg)
 			}
+		}
+	}
 
-			if serverMsg.GetRegistrationResponse() != nil {
-				// No need to respond to the registration responses (they're just acks)
-				continue
+	for {
+		select {
+		case <-shutdown:
+			logger.Info("shutting down worker, waiting for in-flight messages")
+
+			if !drain() {
+				return connected, nil
 			}
 
-			clientMsg, err := handleServerMsg(serverMsg)
-			if err != nil {
-				return err
+			return connected, stream.CloseSend()
+
+		case <-streamCtx.Done():
+			// a message couldn't be handled, or the worker was stopped
+			if !waitInFlight() {
+				return connected, nil
 			}
 
-			err = stream.Send(clientMsg)
-			if err != nil {
-				return err
+			return connected, handlerErr
+
+		case result := <-received:
+			if result.err != nil {
+				if !waitInFlight() {
+					return connected, nil
+				}
+
+				if handlerErr != nil {
+					return connected, handlerErr
+				}
+
+				if errors.Is(result.err, io.EOF) {
+					// Close the stream and exit normally on EOF
+					return connected, stream.CloseSend()
+				}
+
+				return connected, &streamError{result.err}
 			}
+
+			connected = true
+
+			dispatch(result.msg)
+
+		case <-completed:
+			pending--
 		}
 	}
 }
+
+func isClosed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
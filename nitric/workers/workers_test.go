@@ -0,0 +1,458 @@
+// Copyright 2023 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type testClientMsg struct {
+	id string
+}
+
+type testRegistrationResponse struct{}
+
+type testServerMsg struct {
+	id           string
+	registration *testRegistrationResponse
+}
+
+func (t *testServerMsg) GetRegistrationResponse() *testRegistrationResponse {
+	return t.registration
+}
+
+type recvResult struct {
+	msg *testServerMsg
+	err error
+}
+
+// testStream returns the queued results from Recv, then io.EOF.
+// If ctx is set Recv blocks until it is cancelled instead of returning io.EOF.
+type testStream struct {
+	grpc.ClientStream
+	ctx     context.Context
+	results []recvResult
+	sent    []*testClientMsg
+}
+
+func (t *testStream) Send(msg *testClientMsg) error {
+	t.sent = append(t.sent, msg)
+	return nil
+}
+
+func (t *testStream) Recv() (*testServerMsg, error) {
+	if len(t.results) == 0 {
+		if t.ctx != nil {
+			<-t.ctx.Done()
+			return nil, status.Error(codes.Canceled, t.ctx.Err().Error())
+		}
+
+		return nil, io.EOF
+	}
+
+	result := t.results[0]
+	t.results = t.results[1:]
+
+	return result.msg, result.err
+}
+
+func (t *testStream) CloseSend() error {
+	return nil
+}
+
+func echoHandler(msg *testServerMsg) (*testClientMsg, error) {
+	return &testClientMsg{id: msg.id}, nil
+}
+
+var _ = Describe("HandlerContext", func() {
+	When("a timeout is set", func() {
+		It("should set a deadline on the context", func() {
+			ctx, cancel := HandlerContext(context.Background(), time.Minute)
+			defer cancel()
+
+			deadline, ok := ctx.Deadline()
+			Expect(ok).To(BeTrue())
+			Expect(deadline).To(BeTemporally("~", time.Now().Add(time.Minute), time.Second))
+		})
+	})
+
+	When("no timeout is set", func() {
+		It("should not set a deadline on the context", func() {
+			ctx, cancel := HandlerContext(context.Background(), 0)
+			defer cancel()
+
+			_, ok := ctx.Deadline()
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	When("the manager starts shutting down", func() {
+		It("should close the shutdown signal without cancelling the handler context", func() {
+			shutdown, startShutdown := context.WithCancel(context.Background())
+			workerCtx := withWorker(context.Background(), &workerContext{manager: New(), name: "test-worker", shutdown: shutdown})
+
+			ctx, cancel := HandlerContext(workerCtx, 0)
+			defer cancel()
+
+			Expect(ShutdownSignal(ctx)).ToNot(BeClosed())
+
+			startShutdown()
+
+			Expect(ShutdownSignal(ctx)).To(BeClosed())
+			Expect(ctx.Err()).ToNot(HaveOccurred())
+		})
+	})
+
+	When("the worker isn't run by a manager", func() {
+		It("should not return a shutdown signal", func() {
+			ctx, cancel := HandlerContext(context.Background(), 0)
+			defer cancel()
+
+			Expect(ShutdownSignal(ctx)).To(BeNil())
+		})
+	})
+
+	When("the worker context is cancelled", func() {
+		It("should cancel the handler context", func() {
+			workerCtx, cancelWorker := context.WithCancel(context.Background())
+
+			ctx, cancel := HandlerContext(workerCtx, 0)
+			defer cancel()
+
+			cancelWorker()
+
+			Expect(ctx.Err()).To(MatchError(context.Canceled))
+		})
+	})
+})
+
+var _ = Describe("HandleStream", func() {
+	var (
+		m        *Manager
+		events   []ReconnectEvent
+		streams  []*testStream
+		created  int
+		initReq  *testClientMsg
+		register = recvResult{msg: &testServerMsg{registration: &testRegistrationResponse{}}}
+	)
+
+	createStream := func(ctx context.Context) (Stream[testClientMsg, testRegistrationResponse, *testServerMsg], error) {
+		stream := streams[created]
+		created++
+
+		return stream, nil
+	}
+
+	BeforeEach(func() {
+		events = nil
+		created = 0
+		initReq = &testClientMsg{id: "init"}
+
+		m = New()
+		m.SetReconnectPolicy(ReconnectPolicy{
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     time.Millisecond,
+			MaxAttempts:    3,
+		})
+		m.OnReconnect(func(event ReconnectEvent) {
+			events = append(events, event)
+		})
+	})
+
+	When("the stream fails with a retryable error", func() {
+		BeforeEach(func() {
+			streams = []*testStream{
+				{results: []recvResult{register, {err: status.Error(codes.Unavailable, "server restarting")}}},
+				{results: []recvResult{register, {msg: &testServerMsg{id: "1"}}}},
+			}
+		})
+
+		It("should reconnect and resend the registration request", func() {
+			err := HandleStream(withWorker(context.Background(), &workerContext{manager: m, name: "test-worker"}), createStream, initReq, echoHandler)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created).To(Equal(2))
+			Expect(streams[1].sent).To(Equal([]*testClientMsg{initReq, {id: "1"}}))
+
+			By("emitting a reconnect event")
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].Worker).To(Equal("test-worker"))
+			Expect(events[0].Attempt).To(Equal(1))
+			Expect(status.Code(events[0].Err)).To(Equal(codes.Unavailable))
+		})
+	})
+
+	When("the stream fails with a non-retryable error", func() {
+		BeforeEach(func() {
+			streams = []*testStream{
+				{results: []recvResult{{err: status.Error(codes.PermissionDenied, "denied")}}},
+			}
+		})
+
+		It("should return the error", func() {
+			err := HandleStream(withWorker(context.Background(), &workerContext{manager: m, name: "test-worker"}), createStream, initReq, echoHandler)
+
+			Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+			Expect(events).To(BeEmpty())
+		})
+	})
+
+	When("the stream fails with an error from the server or a local cancellation", func() {
+		for _, code := range []codes.Code{codes.Unknown, codes.Internal, codes.Canceled} {
+			code := code
+
+			It("should stop the worker with "+code.String(), func() {
+				streams = []*testStream{
+					{results: []recvResult{register, {err: status.Error(code, "rejected")}}},
+					{results: []recvResult{register}},
+				}
+
+				err := HandleStream(withWorker(context.Background(), &workerContext{manager: m, name: "test-worker"}), createStream, initReq, echoHandler)
+
+				Expect(status.Code(err)).To(Equal(code))
+				Expect(created).To(Equal(1))
+				Expect(events).To(BeEmpty())
+			})
+		}
+	})
+
+	When("the stream keeps failing", func() {
+		BeforeEach(func() {
+			streams = []*testStream{}
+			for i := 0; i < 4; i++ {
+				streams = append(streams, &testStream{results: []recvResult{{err: status.Error(codes.Unavailable, "unavailable")}}})
+			}
+		})
+
+		It("should give up after the max attempts", func() {
+			err := HandleStream(withWorker(context.Background(), &workerContext{manager: m, name: "test-worker"}), createStream, initReq, echoHandler)
+
+			Expect(status.Code(err)).To(Equal(codes.Unavailable))
+			Expect(created).To(Equal(4))
+			Expect(events).To(HaveLen(3))
+		})
+	})
+
+	When("reconnection is disabled", func() {
+		BeforeEach(func() {
+			m.SetReconnectPolicy(ReconnectPolicy{Disabled: true})
+			streams = []*testStream{
+				{results: []recvResult{{err: status.Error(codes.Unavailable, "unavailable")}}},
+			}
+		})
+
+		It("should return the error", func() {
+			err := HandleStream(withWorker(context.Background(), &workerContext{manager: m, name: "test-worker"}), createStream, initReq, echoHandler)
+
+			Expect(status.Code(err)).To(Equal(codes.Unavailable))
+			Expect(created).To(Equal(1))
+		})
+	})
+})
+
+var _ = Describe("HandleStream concurrency", func() {
+	var stream *testStream
+
+	createStream := func(ctx context.Context) (Stream[testClientMsg, testRegistrationResponse, *testServerMsg], error) {
+		return stream, nil
+	}
+
+	BeforeEach(func() {
+		stream = &testStream{results: []recvResult{
+			{msg: &testServerMsg{registration: &testRegistrationResponse{}}},
+			{msg: &testServerMsg{id: "1"}},
+			{msg: &testServerMsg{id: "2"}},
+			{msg: &testServerMsg{id: "3"}},
+		}}
+	})
+
+	When("max in flight is greater than 1", func() {
+		It("should handle messages concurrently", func() {
+			var started int32
+			allStarted := make(chan struct{})
+
+			handler := func(msg *testServerMsg) (*testClientMsg, error) {
+				if atomic.AddInt32(&started, 1) == 3 {
+					close(allStarted)
+				}
+
+				// block until every message is being handled at the same time
+				select {
+				case <-allStarted:
+				case <-time.After(time.Second):
+					return nil, errors.New("messages were not handled concurrently")
+				}
+
+				return &testClientMsg{id: msg.id}, nil
+			}
+
+			err := HandleStream(context.Background(), createStream, &testClientMsg{id: "init"}, handler, WithMaxInFlight(3))
+
+			Expect(err).ToNot(HaveOccurred())
+
+			By("sending a response for every message")
+			Expect(stream.sent).To(ConsistOf(
+				&testClientMsg{id: "init"},
+				&testClientMsg{id: "1"},
+				&testClientMsg{id: "2"},
+				&testClientMsg{id: "3"},
+			))
+		})
+	})
+
+	When("max in flight is not set", func() {
+		It("should handle messages in the order received", func() {
+			var inFlight, maxInFlight int32
+
+			handler := func(msg *testServerMsg) (*testClientMsg, error) {
+				current := atomic.AddInt32(&inFlight, 1)
+				defer atomic.AddInt32(&inFlight, -1)
+
+				if current > atomic.LoadInt32(&maxInFlight) {
+					atomic.StoreInt32(&maxInFlight, current)
+				}
+				time.Sleep(time.Millisecond)
+
+				return &testClientMsg{id: msg.id}, nil
+			}
+
+			err := HandleStream(context.Background(), createStream, &testClientMsg{id: "init"}, handler)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(maxInFlight).To(Equal(int32(1)))
+			Expect(stream.sent).To(Equal([]*testClientMsg{{id: "init"}, {id: "1"}, {id: "2"}, {id: "3"}}))
+		})
+	})
+
+	When("shutdown starts while every in-flight slot is busy", func() {
+		It("should still handle the messages waiting for a slot", func() {
+			recvCtx, stopRecv := context.WithCancel(context.Background())
+			defer stopRecv()
+			stream.ctx = recvCtx
+			stream.results = stream.results[:3]
+
+			shutdown, startShutdown := context.WithCancel(context.Background())
+			defer startShutdown()
+			started := make(chan struct{})
+			release := make(chan struct{})
+
+			handler := func(msg *testServerMsg) (*testClientMsg, error) {
+				if msg.id == "1" {
+					close(started)
+					<-release
+				}
+
+				return &testClientMsg{id: msg.id}, nil
+			}
+
+			ctx := withWorker(context.Background(), &workerContext{manager: New(), name: "test-worker", shutdown: shutdown})
+
+			result := make(chan error, 1)
+			go func() {
+				result <- HandleStream(ctx, createStream, &testClientMsg{id: "init"}, handler, WithMaxInFlight(1))
+			}()
+
+			<-started
+			// give the second message time to be received while the only slot is busy
+			time.Sleep(50 * time.Millisecond)
+			startShutdown()
+			close(release)
+
+			Eventually(result).Should(Receive(BeNil()))
+			Expect(stream.sent).To(Equal([]*testClientMsg{{id: "init"}, {id: "1"}, {id: "2"}}))
+		})
+	})
+
+	When("many messages are handled by a long-lived stream", func() {
+		It("should not leave a goroutine behind for each message", func() {
+			recvCtx, stopRecv := context.WithCancel(context.Background())
+			defer stopRecv()
+			stream.ctx = recvCtx
+			stream.results = stream.results[:1]
+			for i := 0; i < 500; i++ {
+				stream.results = append(stream.results, recvResult{msg: &testServerMsg{id: "msg"}})
+			}
+
+			var handled int32
+			handler := func(msg *testServerMsg) (*testClientMsg, error) {
+				atomic.AddInt32(&handled, 1)
+				return &testClientMsg{id: msg.id}, nil
+			}
+
+			baseline := runtime.NumGoroutine()
+
+			result := make(chan error, 1)
+			go func() {
+				result <- HandleStream(context.Background(), createStream, &testClientMsg{id: "init"}, handler, WithMaxInFlight(5))
+			}()
+
+			Eventually(func() int32 { return atomic.LoadInt32(&handled) }).Should(Equal(int32(500)))
+			// allow for the goroutines running HandleStream and receiving from the stream
+			Eventually(runtime.NumGoroutine).Should(BeNumerically("<=", baseline+5))
+
+			stopRecv()
+			Eventually(result).Should(Receive())
+		})
+	})
+
+	When("a message can't be handled", func() {
+		It("should return the error", func() {
+			handlerErr := errors.New("unhandled server message")
+
+			handler := func(msg *testServerMsg) (*testClientMsg, error) {
+				return nil, handlerErr
+			}
+
+			err := HandleStream(context.Background(), createStream, &testClientMsg{id: "init"}, handler, WithMaxInFlight(3))
+
+			Expect(err).To(Equal(handlerErr))
+		})
+	})
+})
+
+var _ = Describe("ReconnectPolicy", func() {
+	It("should increase the backoff exponentially up to the max", func() {
+		policy := ReconnectPolicy{
+			InitialBackoff: time.Second,
+			MaxBackoff:     5 * time.Second,
+			Multiplier:     2,
+		}
+
+		Expect(policy.backoff(1)).To(Equal(time.Second))
+		Expect(policy.backoff(2)).To(Equal(2 * time.Second))
+		Expect(policy.backoff(3)).To(Equal(4 * time.Second))
+		Expect(policy.backoff(4)).To(Equal(5 * time.Second))
+	})
+
+	It("should apply jitter to the backoff", func() {
+		policy := ReconnectPolicy{
+			InitialBackoff: time.Second,
+			Jitter:         0.2,
+		}
+
+		Expect(policy.backoff(1)).To(BeNumerically("~", time.Second, 200*time.Millisecond))
+	})
+})